@@ -2,14 +2,21 @@ package main
 
 import (
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"football-backend/internal/config"
 	"football-backend/internal/database"
 	"football-backend/internal/handler"
+	"football-backend/internal/jwtkeys"
+	"football-backend/internal/live"
 	"football-backend/internal/middleware"
 	"football-backend/internal/models"
+	"football-backend/internal/notifier"
 	"football-backend/internal/repository"
 	"football-backend/internal/routes"
+	"football-backend/internal/scheduler"
 	"football-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -28,6 +35,88 @@ func main() {
 	if cfg.AppPort == "" {
 		cfg.AppPort = "8080"
 	}
+	if cfg.SchedulerInterval == "" {
+		cfg.SchedulerInterval = "1m"
+	}
+	if cfg.VenueBookingWindow == "" {
+		cfg.VenueBookingWindow = "3h"
+	}
+	if cfg.OfficialAssignmentGap == "" {
+		cfg.OfficialAssignmentGap = "3h"
+	}
+	if cfg.NotifierFile == "" {
+		cfg.NotifierFile = "notifications.log"
+	}
+	if cfg.TOTPIssuer == "" {
+		cfg.TOTPIssuer = "Football Backend"
+	}
+
+	devMode := false
+	if cfg.DevMode != "" {
+		parsed, err := strconv.ParseBool(cfg.DevMode)
+		if err != nil {
+			log.Fatalf("invalid DEV_MODE: %v", err)
+		}
+		devMode = parsed
+	}
+
+	var totpRequiredRoles []string
+	for _, role := range strings.Split(cfg.TOTPRequiredRoles, ",") {
+		role = strings.ToUpper(strings.TrimSpace(role))
+		if role == "" {
+			continue
+		}
+		if role != "ADMIN" && role != "STAFF" && role != "VIEWER" {
+			log.Fatalf("invalid TOTP_REQUIRED_ROLES: unknown role %q", role)
+		}
+		totpRequiredRoles = append(totpRequiredRoles, role)
+	}
+
+	var liveAllowedOrigins []string
+	for _, origin := range strings.Split(cfg.LiveAllowedOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			liveAllowedOrigins = append(liveAllowedOrigins, origin)
+		}
+	}
+
+	venueBookingWindow, err := time.ParseDuration(cfg.VenueBookingWindow)
+	if err != nil {
+		log.Fatalf("invalid VENUE_BOOKING_WINDOW: %v", err)
+	}
+	officialAssignmentGap, err := time.ParseDuration(cfg.OfficialAssignmentGap)
+	if err != nil {
+		log.Fatalf("invalid OFFICIAL_ASSIGNMENT_GAP: %v", err)
+	}
+
+	var jwtKeys *jwtkeys.KeySet
+	if cfg.JWTKeysDir == "" {
+		if !devMode {
+			log.Fatal("JWT_KEYS_DIR is not set; set DEV_MODE=true to use an ephemeral key for local development")
+		}
+		log.Printf("warning: JWT_KEYS_DIR is not set, using an ephemeral EdDSA key; tokens will not survive a restart")
+		jwtKeys, err = jwtkeys.Generate("ephemeral")
+	} else {
+		jwtKeys, err = jwtkeys.LoadDir(cfg.JWTKeysDir, cfg.JWTSigningKeyID)
+	}
+	if err != nil {
+		log.Fatalf("jwt keys load failed: %v", err)
+	}
+	log.Printf("signing JWTs with key %s", jwtKeys.SigningKeyID())
+
+	var notify notifier.Notifier
+	switch cfg.Notifier {
+	case "log":
+		if !devMode {
+			log.Printf("warning: NOTIFIER=log only logs message subjects, set DEV_MODE=true to log reset tokens")
+		}
+		notify = notifier.NewLogNotifier(devMode)
+	case "file":
+		notify = notifier.NewFileNotifier(cfg.NotifierFile)
+	case "":
+		log.Fatal("NOTIFIER is not set (log, file)")
+	default:
+		log.Fatalf("invalid NOTIFIER: %q (log, file)", cfg.Notifier)
+	}
 
 	if err := database.Connect(cfg); err != nil {
 		log.Fatalf("database connect error: %v", err)
@@ -39,38 +128,141 @@ func main() {
 	}
 
 	if err := db.AutoMigrate(
+		&models.Competition{},
+		&models.Season{},
+		&models.Venue{},
 		&models.Team{},
 		&models.Player{},
 		&models.Match{},
+		&models.Bracket{},
+		&models.KnockoutTie{},
+		&models.PenaltyKick{},
+		&models.Official{},
+		&models.MatchOfficial{},
+		&models.GroupStage{},
+		&models.TournamentGroup{},
+		&models.StandingRule{},
+		&models.TeamSanction{},
 		&models.Goal{},
+		&models.MatchEvent{},
+		&models.MatchEventRevision{},
+		&models.MatchLineup{},
+		&models.LineupPlayer{},
 		&models.User{},
+		&models.TransferWindow{},
+		&models.PlayerLoan{},
+		&models.PlayerContract{},
+		&models.SquadRule{},
+		&models.DisciplineRule{},
+		&models.PlayerInjury{},
+		&models.PlayerSuspension{},
 		&models.PlayerTransfer{},
+		&models.UserSession{},
 		&models.RefreshToken{},
+		&models.PasswordResetToken{},
+		&models.RecoveryCode{},
+		&models.TwoFactorChallenge{},
 	); err != nil {
 		log.Fatalf("auto migrate failed: %v", err)
 	}
 
+	legacyTokens, err := repository.NewRefreshTokenRepository(db).HashLegacyTokens(time.Now())
+	if err != nil {
+		log.Fatalf("refresh token migration failed: %v", err)
+	}
+	if legacyTokens > 0 {
+		log.Printf("revoked %d legacy plaintext refresh tokens; affected users must log in again", legacyTokens)
+	}
+
+	venueRepo := repository.NewVenueRepository(db)
 	teamRepo := repository.NewTeamRepository(db)
 	playerRepo := repository.NewPlayerRepository(db)
 	playerTransferRepo := repository.NewPlayerTransferRepository(db)
+	playerStatRepo := repository.NewPlayerStatRepository(db)
+	transferWindowRepo := repository.NewTransferWindowRepository(db)
+	playerLoanRepo := repository.NewPlayerLoanRepository(db)
+	playerContractRepo := repository.NewPlayerContractRepository(db)
+	squadRuleRepo := repository.NewSquadRuleRepository(db)
+	disciplineRuleRepo := repository.NewDisciplineRuleRepository(db)
+	injuryRepo := repository.NewPlayerInjuryRepository(db)
+	suspensionRepo := repository.NewPlayerSuspensionRepository(db)
 	matchRepo := repository.NewMatchRepository(db)
 	goalRepo := repository.NewGoalRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	refreshRepo := repository.NewRefreshTokenRepository(db)
+	sessionRepo := repository.NewUserSessionRepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db)
+	challengeRepo := repository.NewTwoFactorChallengeRepository(db)
+	competitionRepo := repository.NewCompetitionRepository(db)
+	seasonRepo := repository.NewSeasonRepository(db)
+	bracketRepo := repository.NewBracketRepository(db)
+	penaltyRepo := repository.NewPenaltyKickRepository(db)
+	groupStageRepo := repository.NewGroupStageRepository(db)
+	standingRuleRepo := repository.NewStandingRuleRepository(db)
+	sanctionRepo := repository.NewTeamSanctionRepository(db)
+	matchEventRepo := repository.NewMatchEventRepository(db)
+	lineupRepo := repository.NewMatchLineupRepository(db)
+	eventRevisionRepo := repository.NewMatchEventRevisionRepository(db)
+	officialRepo := repository.NewOfficialRepository(db)
+	matchOfficialRepo := repository.NewMatchOfficialRepository(db)
+	uow := repository.NewUnitOfWork(db)
 
-	teamSvc := service.NewTeamService(teamRepo)
-	playerSvc := service.NewPlayerService(playerRepo, playerTransferRepo, teamRepo)
-	goalSvc := service.NewGoalService(goalRepo, matchRepo)
-	matchSvc := service.NewMatchService(matchRepo, goalRepo, teamRepo)
-	authSvc := service.NewAuthService(userRepo, refreshRepo)
+	liveHub, err := live.NewHub(live.NewMemoryBackend(), live.DefaultBufferSize, live.DefaultHeartbeat)
+	if err != nil {
+		log.Fatalf("live hub init failed: %v", err)
+	}
+	defer liveHub.Close()
+
+	teamSvc := service.NewTeamService(teamRepo, matchRepo, playerTransferRepo, venueRepo)
+	playerSvc := service.NewPlayerService(playerRepo, playerTransferRepo, teamRepo, playerStatRepo, transferWindowRepo, playerContractRepo, uow)
+	availabilitySvc := service.NewPlayerAvailabilityService(injuryRepo, suspensionRepo, playerRepo, teamRepo, matchRepo, competitionRepo)
+	playerLoanSvc := service.NewPlayerLoanService(playerLoanRepo, playerRepo, teamRepo, transferWindowRepo, uow)
+	matchEventSvc := service.NewMatchEventService(matchEventRepo, matchRepo, goalRepo, playerRepo, lineupRepo, eventRevisionRepo, suspensionRepo, liveHub, uow, venueBookingWindow)
+	lineupSvc := service.NewMatchLineupService(lineupRepo, matchRepo, playerRepo, suspensionRepo, uow)
+	goalSvc := service.NewGoalService(goalRepo, matchEventSvc)
+	bracketSvc := service.NewBracketService(bracketRepo, matchRepo, teamRepo, playerRepo, seasonRepo, penaltyRepo, uow, venueBookingWindow)
+	matchSvc := service.NewMatchService(matchRepo, goalRepo, teamRepo, seasonRepo, standingRuleRepo, sanctionRepo, matchEventRepo, venueRepo, liveHub, uow, venueBookingWindow, officialAssignmentGap)
+	backfilled, err := matchSvc.BackfillResults()
+	if err != nil {
+		log.Fatalf("match result backfill failed: %v", err)
+	}
+	if backfilled > 0 {
+		log.Printf("backfilled result for %d finished matches", backfilled)
+	}
+	authSvc := service.NewAuthService(userRepo, refreshRepo, sessionRepo, passwordResetRepo, recoveryCodeRepo, challengeRepo, notify, jwtKeys, uow)
+	twoFactorSvc := service.NewTwoFactorService(userRepo, recoveryCodeRepo, sessionRepo, refreshRepo, uow, totpRequiredRoles, cfg.TOTPIssuer)
 	userSvc := service.NewUserService(userRepo)
+	competitionSvc := service.NewCompetitionService(competitionRepo, seasonRepo, matchRepo, teamRepo, standingRuleRepo, sanctionRepo, transferWindowRepo, squadRuleRepo, disciplineRuleRepo)
+	venueSvc := service.NewVenueService(venueRepo)
+	officialSvc := service.NewOfficialService(officialRepo, matchOfficialRepo, matchRepo, uow, officialAssignmentGap)
+	groupStageSvc := service.NewGroupStageService(groupStageRepo, matchRepo, teamRepo, seasonRepo, standingRuleRepo, sanctionRepo, matchEventRepo, bracketSvc, uow, venueBookingWindow)
 
-	authHandler := handler.NewAuthHandler(authSvc)
+	authHandler := handler.NewAuthHandler(authSvc, twoFactorSvc)
 	userHandler := handler.NewUserHandler(userSvc)
-	teamHandler := handler.NewTeamHandler(teamSvc)
-	playerHandler := handler.NewPlayerHandler(playerSvc)
+	teamHandler := handler.NewTeamHandler(teamSvc, availabilitySvc)
+	playerHandler := handler.NewPlayerHandler(playerSvc, playerLoanSvc, availabilitySvc)
 	goalHandler := handler.NewGoalHandler(goalSvc)
-	matchHandler := handler.NewMatchHandler(matchSvc, goalSvc)
+	matchHandler := handler.NewMatchHandler(matchSvc)
+	competitionHandler := handler.NewCompetitionHandler(competitionSvc, matchSvc, goalSvc)
+	bracketHandler := handler.NewBracketHandler(bracketSvc)
+	groupStageHandler := handler.NewGroupStageHandler(groupStageSvc)
+	matchEventHandler := handler.NewMatchEventHandler(matchEventSvc)
+	lineupHandler := handler.NewMatchLineupHandler(lineupSvc)
+	liveHandler := handler.NewLiveHandler(liveHub, matchSvc, liveAllowedOrigins)
+	officialHandler := handler.NewOfficialHandler(officialSvc)
+	venueHandler := handler.NewVenueHandler(venueSvc)
+
+	schedulerInterval, err := time.ParseDuration(cfg.SchedulerInterval)
+	if err != nil {
+		log.Fatalf("invalid SCHEDULER_INTERVAL: %v", err)
+	}
+	jobs := scheduler.New(
+		scheduler.Job{Name: "player-loans", Interval: schedulerInterval, Run: playerLoanSvc.ProcessDue},
+		scheduler.Job{Name: "refresh-tokens", Interval: schedulerInterval, Run: authSvc.PurgeExpiredTokens},
+	)
+	jobs.Start()
+	defer jobs.Stop()
 
 	r := gin.New()
 	r.Use(middleware.JSONLogger())
@@ -84,7 +276,18 @@ func main() {
 		playerHandler,
 		matchHandler,
 		goalHandler,
+		competitionHandler,
+		bracketHandler,
+		groupStageHandler,
+		matchEventHandler,
+		lineupHandler,
+		liveHandler,
+		officialHandler,
+		venueHandler,
 		userRepo,
+		sessionRepo,
+		jwtKeys,
+		totpRequiredRoles,
 	)
 
 	r.GET("/health", func(c *gin.Context) {
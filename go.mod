@@ -10,7 +10,9 @@ require (
 	gorm.io/gorm v1.31.1
 )
 
-require github.com/google/uuid v1.6.0 // indirect
+require github.com/google/uuid v1.6.0
+
+require github.com/gorilla/websocket v1.5.3
 
 require (
 	filippo.io/edwards25519 v1.1.0 // indirect
@@ -5,23 +5,53 @@ import (
 )
 
 type Config struct {
-	DBHost    string
-	DBPort    string
-	DBUser    string
-	DBPass    string
-	DBName    string
-	JWTSecret string
-	AppPort   string
+	DBHost  string
+	DBPort  string
+	DBUser  string
+	DBPass  string
+	DBName  string
+	AppPort string
+	DevMode string
+
+	JWTKeysDir      string
+	JWTSigningKeyID string
+
+	Notifier     string
+	NotifierFile string
+
+	TOTPIssuer        string
+	TOTPRequiredRoles string
+
+	LiveAllowedOrigins string
+
+	SchedulerInterval     string
+	VenueBookingWindow    string
+	OfficialAssignmentGap string
 }
 
 func Load() *Config {
 	return &Config{
-		DBHost:    os.Getenv("DB_HOST"),
-		DBPort:    os.Getenv("DB_PORT"),
-		DBUser:    os.Getenv("DB_USER"),
-		DBPass:    os.Getenv("DB_PASS"),
-		DBName:    os.Getenv("DB_NAME"),
-		JWTSecret: os.Getenv("JWT_SECRET"),
-		AppPort:   os.Getenv("APP_PORT"),
+		DBHost:  os.Getenv("DB_HOST"),
+		DBPort:  os.Getenv("DB_PORT"),
+		DBUser:  os.Getenv("DB_USER"),
+		DBPass:  os.Getenv("DB_PASS"),
+		DBName:  os.Getenv("DB_NAME"),
+		AppPort: os.Getenv("APP_PORT"),
+		DevMode: os.Getenv("DEV_MODE"),
+
+		JWTKeysDir:      os.Getenv("JWT_KEYS_DIR"),
+		JWTSigningKeyID: os.Getenv("JWT_SIGNING_KEY_ID"),
+
+		Notifier:     os.Getenv("NOTIFIER"),
+		NotifierFile: os.Getenv("NOTIFIER_FILE"),
+
+		TOTPIssuer:        os.Getenv("TOTP_ISSUER"),
+		TOTPRequiredRoles: os.Getenv("TOTP_REQUIRED_ROLES"),
+
+		LiveAllowedOrigins: os.Getenv("LIVE_ALLOWED_ORIGINS"),
+
+		SchedulerInterval:     os.Getenv("SCHEDULER_INTERVAL"),
+		VenueBookingWindow:    os.Getenv("VENUE_BOOKING_WINDOW"),
+		OfficialAssignmentGap: os.Getenv("OFFICIAL_ASSIGNMENT_GAP"),
 	}
 }
@@ -0,0 +1,170 @@
+package dto
+
+import (
+	"fmt"
+	"football-backend/internal/models"
+	"time"
+)
+
+type TieLegDTO struct {
+	MatchID       uint   `json:"match_id"`
+	Leg           int    `json:"leg"`
+	MatchDate     string `json:"match_date"`
+	Status        string `json:"status"`
+	HomeTeamID    uint   `json:"home_team_id"`
+	AwayTeamID    uint   `json:"away_team_id"`
+	HomeScore     int    `json:"home_score"`
+	AwayScore     int    `json:"away_score"`
+	ExtraTime     bool   `json:"extra_time"`
+	HomePenalties *int   `json:"home_penalties"`
+	AwayPenalties *int   `json:"away_penalties"`
+}
+
+type TieDTO struct {
+	ID            uint           `json:"id"`
+	Position      int            `json:"position"`
+	HomeTeam      *TeamSimpleDTO `json:"home_team"`
+	AwayTeam      *TeamSimpleDTO `json:"away_team"`
+	Legs          []TieLegDTO    `json:"legs"`
+	AggregateHome int            `json:"aggregate_home"`
+	AggregateAway int            `json:"aggregate_away"`
+	WinnerTeamID  *uint          `json:"winner_team_id"`
+	DecidedBy     string         `json:"decided_by"`
+	NextTieID     *uint          `json:"next_tie_id"`
+}
+
+type BracketRoundDTO struct {
+	Round int      `json:"round"`
+	Name  string   `json:"name"`
+	Ties  []TieDTO `json:"ties"`
+}
+
+type BracketDTO struct {
+	ID            uint              `json:"id"`
+	SeasonID      uint              `json:"season_id"`
+	Name          string            `json:"name"`
+	Size          int               `json:"size"`
+	Seeded        bool              `json:"seeded"`
+	TwoLegged     bool              `json:"two_legged"`
+	AwayGoalsRule bool              `json:"away_goals_rule"`
+	ExtraTime     bool              `json:"extra_time"`
+	Rounds        []BracketRoundDTO `json:"rounds"`
+}
+
+func RoundName(round, size int) string {
+	remaining := size
+	for i := 1; i < round; i++ {
+		remaining /= 2
+	}
+	switch remaining {
+	case 2:
+		return "Final"
+	case 4:
+		return "Semifinal"
+	case 8:
+		return "Perempat Final"
+	default:
+		return fmt.Sprintf("Babak %d Besar", remaining)
+	}
+}
+
+func toTeamSimplePtr(t *models.Team) *TeamSimpleDTO {
+	if t == nil {
+		return nil
+	}
+	return &TeamSimpleDTO{ID: t.ID, Name: t.Name}
+}
+
+func penaltyScore(m *models.Match) (*int, *int) {
+	if len(m.PenaltyKicks) == 0 {
+		return nil, nil
+	}
+	home := 0
+	away := 0
+	for _, k := range m.PenaltyKicks {
+		if !k.Scored {
+			continue
+		}
+		if k.TeamID == m.HomeTeamID {
+			home++
+		} else {
+			away++
+		}
+	}
+	return &home, &away
+}
+
+func ToTieDTO(t *models.KnockoutTie) TieDTO {
+	legs := make([]TieLegDTO, 0, len(t.Matches))
+	aggHome := 0
+	aggAway := 0
+
+	for i := range t.Matches {
+		m := &t.Matches[i]
+		homeScore, awayScore := MatchScore(m)
+
+		if t.HomeTeamID != nil && m.HomeTeamID == *t.HomeTeamID {
+			aggHome += homeScore
+			aggAway += awayScore
+		} else {
+			aggHome += awayScore
+			aggAway += homeScore
+		}
+
+		homePens, awayPens := penaltyScore(m)
+		legs = append(legs, TieLegDTO{
+			MatchID:       m.ID,
+			Leg:           m.Leg,
+			MatchDate:     m.MatchDateTime.Format(time.RFC3339),
+			Status:        m.Status,
+			HomeTeamID:    m.HomeTeamID,
+			AwayTeamID:    m.AwayTeamID,
+			HomeScore:     homeScore,
+			AwayScore:     awayScore,
+			ExtraTime:     m.ExtraTime,
+			HomePenalties: homePens,
+			AwayPenalties: awayPens,
+		})
+	}
+
+	return TieDTO{
+		ID:            t.ID,
+		Position:      t.Position,
+		HomeTeam:      toTeamSimplePtr(t.HomeTeam),
+		AwayTeam:      toTeamSimplePtr(t.AwayTeam),
+		Legs:          legs,
+		AggregateHome: aggHome,
+		AggregateAway: aggAway,
+		WinnerTeamID:  t.WinnerTeamID,
+		DecidedBy:     t.DecidedBy,
+		NextTieID:     t.NextTieID,
+	}
+}
+
+func ToBracketDTO(b *models.Bracket) BracketDTO {
+	rounds := []BracketRoundDTO{}
+	for i := range b.Ties {
+		t := &b.Ties[i]
+		if len(rounds) == 0 || rounds[len(rounds)-1].Round != t.Round {
+			rounds = append(rounds, BracketRoundDTO{
+				Round: t.Round,
+				Name:  RoundName(t.Round, b.Size),
+				Ties:  []TieDTO{},
+			})
+		}
+		last := &rounds[len(rounds)-1]
+		last.Ties = append(last.Ties, ToTieDTO(t))
+	}
+
+	return BracketDTO{
+		ID:            b.ID,
+		SeasonID:      b.SeasonID,
+		Name:          b.Name,
+		Size:          b.Size,
+		Seeded:        b.Seeded,
+		TwoLegged:     b.TwoLegged,
+		AwayGoalsRule: b.AwayGoalsRule,
+		ExtraTime:     b.ExtraTime,
+		Rounds:        rounds,
+	}
+}
@@ -0,0 +1,157 @@
+package dto
+
+import (
+	"football-backend/internal/models"
+	"strings"
+	"time"
+)
+
+type SeasonDTO struct {
+	ID            uint   `json:"id"`
+	CompetitionID uint   `json:"competition_id"`
+	Name          string `json:"name"`
+	StartDate     string `json:"start_date"`
+	EndDate       string `json:"end_date"`
+	IsCurrent     bool   `json:"is_current"`
+}
+
+type SeasonSimpleDTO struct {
+	ID              uint   `json:"id"`
+	Name            string `json:"name"`
+	CompetitionID   uint   `json:"competition_id"`
+	CompetitionName string `json:"competition_name"`
+}
+
+type CompetitionDTO struct {
+	ID      uint        `json:"id"`
+	Name    string      `json:"name"`
+	Type    string      `json:"type"`
+	Country string      `json:"country"`
+	LogoURL string      `json:"logo_url"`
+	Seasons []SeasonDTO `json:"seasons,omitempty"`
+}
+
+func ToSeasonDTO(s *models.Season) SeasonDTO {
+	return SeasonDTO{
+		ID:            s.ID,
+		CompetitionID: s.CompetitionID,
+		Name:          s.Name,
+		StartDate:     s.StartDate.Format(time.DateOnly),
+		EndDate:       s.EndDate.Format(time.DateOnly),
+		IsCurrent:     s.IsCurrent,
+	}
+}
+
+func ToSeasonDTOList(list []models.Season) []SeasonDTO {
+	result := make([]SeasonDTO, 0, len(list))
+	for _, s := range list {
+		result = append(result, ToSeasonDTO(&s))
+	}
+	return result
+}
+
+func ToCompetitionDTO(c *models.Competition) CompetitionDTO {
+	return CompetitionDTO{
+		ID:      c.ID,
+		Name:    c.Name,
+		Type:    c.Type,
+		Country: c.Country,
+		LogoURL: c.LogoURL,
+		Seasons: ToSeasonDTOList(c.Seasons),
+	}
+}
+
+func ToCompetitionDTOList(list []models.Competition) []CompetitionDTO {
+	result := make([]CompetitionDTO, 0, len(list))
+	for _, c := range list {
+		result = append(result, ToCompetitionDTO(&c))
+	}
+	return result
+}
+
+type StandingRuleDTO struct {
+	CompetitionID uint     `json:"competition_id"`
+	PointsWin     int      `json:"points_win"`
+	PointsDraw    int      `json:"points_draw"`
+	PointsLoss    int      `json:"points_loss"`
+	Tiebreakers   []string `json:"tiebreakers"`
+}
+
+type SquadRuleDTO struct {
+	CompetitionID     uint `json:"competition_id"`
+	MaxSquadSize      int  `json:"max_squad_size"`
+	MaxForeignPlayers int  `json:"max_foreign_players"`
+}
+
+type DisciplineRuleDTO struct {
+	CompetitionID       uint `json:"competition_id"`
+	YellowCardThreshold int  `json:"yellow_card_threshold"`
+	YellowCardBan       int  `json:"yellow_card_ban"`
+	SecondYellowBan     int  `json:"second_yellow_ban"`
+	RedCardBan          int  `json:"red_card_ban"`
+}
+
+type TeamSanctionDTO struct {
+	ID             uint          `json:"id"`
+	SeasonID       uint          `json:"season_id"`
+	Team           TeamSimpleDTO `json:"team"`
+	PointsDeducted int           `json:"points_deducted"`
+	FairPlayPoints int           `json:"fair_play_points"`
+	Reason         string        `json:"reason"`
+	CreatedAt      string        `json:"created_at"`
+}
+
+func ToStandingRuleDTO(r *models.StandingRule) StandingRuleDTO {
+	list := []string{}
+	for _, t := range strings.Split(r.Tiebreakers, ",") {
+		if t != "" {
+			list = append(list, t)
+		}
+	}
+
+	return StandingRuleDTO{
+		CompetitionID: r.CompetitionID,
+		PointsWin:     r.PointsWin,
+		PointsDraw:    r.PointsDraw,
+		PointsLoss:    r.PointsLoss,
+		Tiebreakers:   list,
+	}
+}
+
+func ToSquadRuleDTO(r *models.SquadRule) SquadRuleDTO {
+	return SquadRuleDTO{
+		CompetitionID:     r.CompetitionID,
+		MaxSquadSize:      r.MaxSquadSize,
+		MaxForeignPlayers: r.MaxForeignPlayers,
+	}
+}
+
+func ToDisciplineRuleDTO(r *models.DisciplineRule) DisciplineRuleDTO {
+	return DisciplineRuleDTO{
+		CompetitionID:       r.CompetitionID,
+		YellowCardThreshold: r.YellowCardThreshold,
+		YellowCardBan:       r.YellowCardBan,
+		SecondYellowBan:     r.SecondYellowBan,
+		RedCardBan:          r.RedCardBan,
+	}
+}
+
+func ToTeamSanctionDTO(s *models.TeamSanction) TeamSanctionDTO {
+	return TeamSanctionDTO{
+		ID:             s.ID,
+		SeasonID:       s.SeasonID,
+		Team:           TeamSimpleDTO{ID: s.Team.ID, Name: s.Team.Name},
+		PointsDeducted: s.PointsDeducted,
+		FairPlayPoints: s.FairPlayPoints,
+		Reason:         s.Reason,
+		CreatedAt:      s.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToTeamSanctionDTOList(list []models.TeamSanction) []TeamSanctionDTO {
+	result := make([]TeamSanctionDTO, 0, len(list))
+	for _, s := range list {
+		result = append(result, ToTeamSanctionDTO(&s))
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"football-backend/internal/models"
+	"time"
+)
+
+type FixtureDTO struct {
+	MatchID   uint          `json:"match_id,omitempty"`
+	Round     int           `json:"round"`
+	MatchDate string        `json:"match_date"`
+	HomeTeam  TeamSimpleDTO `json:"home_team"`
+	AwayTeam  TeamSimpleDTO `json:"away_team"`
+}
+
+func ToFixtureDTOList(list []models.Match) []FixtureDTO {
+	result := make([]FixtureDTO, 0, len(list))
+	for _, m := range list {
+		result = append(result, FixtureDTO{
+			MatchID:   m.ID,
+			Round:     m.Round,
+			MatchDate: m.MatchDateTime.Format(time.RFC3339),
+			HomeTeam:  TeamSimpleDTO{ID: m.HomeTeam.ID, Name: m.HomeTeam.Name},
+			AwayTeam:  TeamSimpleDTO{ID: m.AwayTeam.ID, Name: m.AwayTeam.Name},
+		})
+	}
+	return result
+}
@@ -3,17 +3,37 @@ package dto
 import "football-backend/internal/models"
 
 type GoalDTO struct {
-	ID     uint      `json:"id"`
-	Minute string    `json:"minute"`
-	Team   TeamDTO   `json:"team"`
-	Scorer PlayerDTO `json:"scorer"`
+	ID     uint       `json:"id"`
+	Minute string     `json:"minute"`
+	Type   string     `json:"type"`
+	Team   TeamDTO    `json:"team"`
+	Scorer PlayerDTO  `json:"scorer"`
+	Assist *PlayerDTO `json:"assist"`
 }
 
 func ToGoalDTO(g *models.Goal) GoalDTO {
+	var assist *PlayerDTO
+	if g.Assist != nil {
+		p := ToPlayerDTO(g.Assist)
+		assist = &p
+	}
+
 	return GoalDTO{
 		ID:     g.ID,
 		Minute: g.Minute,
+		Type:   g.Type,
 		Team:   ToTeamDTO(&g.Team),
 		Scorer: ToPlayerDTO(&g.Scorer),
+		Assist: assist,
+	}
+}
+
+func ScoringTeamID(g *models.Goal, m *models.Match) uint {
+	if g.Type != "BUNUH_DIRI" {
+		return g.TeamID
+	}
+	if g.TeamID == m.HomeTeamID {
+		return m.AwayTeamID
 	}
+	return m.HomeTeamID
 }
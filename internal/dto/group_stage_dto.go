@@ -0,0 +1,60 @@
+package dto
+
+import "football-backend/internal/models"
+
+type GroupDTO struct {
+	ID    uint            `json:"id"`
+	Name  string          `json:"name"`
+	Teams []TeamSimpleDTO `json:"teams"`
+}
+
+type GroupStageDTO struct {
+	ID                 uint       `json:"id"`
+	SeasonID           uint       `json:"season_id"`
+	Name               string     `json:"name"`
+	QualifiersPerGroup int        `json:"qualifiers_per_group"`
+	BestThirdPlaced    int        `json:"best_third_placed"`
+	DrawRule           string     `json:"draw_rule"`
+	TwoLegged          bool       `json:"two_legged"`
+	AwayGoalsRule      bool       `json:"away_goals_rule"`
+	ExtraTime          bool       `json:"extra_time"`
+	BracketID          *uint      `json:"bracket_id"`
+	Groups             []GroupDTO `json:"groups"`
+}
+
+func ToGroupStageDTO(gs *models.GroupStage) GroupStageDTO {
+	groups := make([]GroupDTO, 0, len(gs.Groups))
+	for _, g := range gs.Groups {
+		teams := make([]TeamSimpleDTO, 0, len(g.Teams))
+		for _, t := range g.Teams {
+			teams = append(teams, TeamSimpleDTO{ID: t.ID, Name: t.Name})
+		}
+		groups = append(groups, GroupDTO{
+			ID:    g.ID,
+			Name:  g.Name,
+			Teams: teams,
+		})
+	}
+
+	return GroupStageDTO{
+		ID:                 gs.ID,
+		SeasonID:           gs.SeasonID,
+		Name:               gs.Name,
+		QualifiersPerGroup: gs.QualifiersPerGroup,
+		BestThirdPlaced:    gs.BestThirdPlaced,
+		DrawRule:           gs.DrawRule,
+		TwoLegged:          gs.TwoLegged,
+		AwayGoalsRule:      gs.AwayGoalsRule,
+		ExtraTime:          gs.ExtraTime,
+		BracketID:          gs.BracketID,
+		Groups:             groups,
+	}
+}
+
+func ToGroupStageDTOList(list []models.GroupStage) []GroupStageDTO {
+	result := make([]GroupStageDTO, 0, len(list))
+	for _, gs := range list {
+		result = append(result, ToGroupStageDTO(&gs))
+	}
+	return result
+}
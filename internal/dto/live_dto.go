@@ -0,0 +1,43 @@
+package dto
+
+import "football-backend/internal/models"
+
+type LiveMatchDTO struct {
+	MatchID   uint           `json:"match_id"`
+	HomeTeam  TeamSimpleDTO  `json:"home_team"`
+	AwayTeam  TeamSimpleDTO  `json:"away_team"`
+	Status    string         `json:"status"`
+	Result    *string        `json:"result"`
+	HomeScore int            `json:"home_score"`
+	AwayScore int            `json:"away_score"`
+	Event     *MatchEventDTO `json:"event,omitempty"`
+}
+
+func ToLiveMatchDTO(m *models.Match, e *models.MatchEvent) LiveMatchDTO {
+	homeScore, awayScore := MatchScore(m)
+
+	var event *MatchEventDTO
+	if e != nil {
+		ev := ToMatchEventDTO(e, homeScore, awayScore)
+		event = &ev
+	}
+
+	return LiveMatchDTO{
+		MatchID:   m.ID,
+		HomeTeam:  TeamSimpleDTO{ID: m.HomeTeam.ID, Name: m.HomeTeam.Name},
+		AwayTeam:  TeamSimpleDTO{ID: m.AwayTeam.ID, Name: m.AwayTeam.Name},
+		Status:    m.Status,
+		Result:    m.Result,
+		HomeScore: homeScore,
+		AwayScore: awayScore,
+		Event:     event,
+	}
+}
+
+func ToLiveMatchDTOList(list []models.Match) []LiveMatchDTO {
+	result := make([]LiveMatchDTO, 0, len(list))
+	for _, m := range list {
+		result = append(result, ToLiveMatchDTO(&m, nil))
+	}
+	return result
+}
@@ -6,41 +6,67 @@ import (
 )
 
 type MatchDTO struct {
-	ID        uint      `json:"id"`
-	MatchDate string    `json:"match_date"`
-	Status    string    `json:"status"`
-	HomeTeam  TeamDTO   `json:"home_team"`
-	AwayTeam  TeamDTO   `json:"away_team"`
-	Goals     []GoalDTO `json:"goals"`
-	HomeScore int       `json:"home_score"`
-	AwayScore int       `json:"away_score"`
+	ID        uint             `json:"id"`
+	MatchDate string           `json:"match_date"`
+	Status    string           `json:"status"`
+	Result    *string          `json:"result"`
+	Season    *SeasonSimpleDTO `json:"season"`
+	Round     int              `json:"round"`
+	GroupID   *uint            `json:"group_id,omitempty"`
+	TieID     *uint            `json:"tie_id,omitempty"`
+	Leg       int              `json:"leg,omitempty"`
+	HomeTeam  TeamDTO          `json:"home_team"`
+	AwayTeam  TeamDTO          `json:"away_team"`
+	Venue     *VenueSimpleDTO  `json:"venue"`
+	Goals     []GoalDTO        `json:"goals"`
+	HomeScore int              `json:"home_score"`
+	AwayScore int              `json:"away_score"`
+	ExtraTime bool             `json:"extra_time"`
+
+	HomePenalties *int `json:"home_penalties"`
+	AwayPenalties *int `json:"away_penalties"`
 }
 
 func ToMatchDTO(m *models.Match) MatchDTO {
-	homeScore := 0
-	awayScore := 0
-	for _, g := range m.Goals {
-		if g.TeamID == m.HomeTeamID {
-			homeScore++
-		} else {
-			awayScore++
-		}
-	}
+	homeScore, awayScore := MatchScore(m)
 
 	goals := make([]GoalDTO, 0)
 	for _, g := range m.Goals {
 		goals = append(goals, ToGoalDTO(&g))
 	}
 
+	var season *SeasonSimpleDTO
+	if m.Season != nil {
+		season = &SeasonSimpleDTO{
+			ID:              m.Season.ID,
+			Name:            m.Season.Name,
+			CompetitionID:   m.Season.CompetitionID,
+			CompetitionName: m.Season.Competition.Name,
+		}
+	}
+
+	homePens, awayPens := penaltyScore(m)
+
 	return MatchDTO{
 		ID:        m.ID,
 		MatchDate: m.MatchDateTime.Format(time.RFC3339),
 		Status:    m.Status,
+		Result:    m.Result,
+		Season:    season,
+		Round:     m.Round,
+		GroupID:   m.GroupID,
+		TieID:     m.TieID,
+		Leg:       m.Leg,
 		HomeTeam:  ToTeamDTO(&m.HomeTeam),
 		AwayTeam:  ToTeamDTO(&m.AwayTeam),
+		Venue:     ToVenueSimpleDTO(m.Venue),
 		Goals:     goals,
 		HomeScore: homeScore,
 		AwayScore: awayScore,
+		ExtraTime: m.ExtraTime,
+
+		HomePenalties: homePens,
+		AwayPenalties: awayPens,
 	}
 }
 
@@ -51,3 +77,16 @@ func ToMatchDTOList(list []models.Match) []MatchDTO {
 	}
 	return result
 }
+
+func MatchScore(m *models.Match) (int, int) {
+	home := 0
+	away := 0
+	for _, g := range m.Goals {
+		if ScoringTeamID(&g, m) == m.HomeTeamID {
+			home++
+		} else {
+			away++
+		}
+	}
+	return home, away
+}
@@ -0,0 +1,96 @@
+package dto
+
+import "football-backend/internal/models"
+
+type PlayerSimpleDTO struct {
+	ID           uint   `json:"id"`
+	Name         string `json:"name"`
+	JerseyNumber int    `json:"jersey_number"`
+}
+
+type MatchEventDTO struct {
+	ID            uint             `json:"id"`
+	Type          string           `json:"type"`
+	Minute        string           `json:"minute"`
+	Team          TeamSimpleDTO    `json:"team"`
+	Player        PlayerSimpleDTO  `json:"player"`
+	RelatedPlayer *PlayerSimpleDTO `json:"related_player"`
+	GoalID        *uint            `json:"goal_id,omitempty"`
+	Note          string           `json:"note,omitempty"`
+	HomeScore     int              `json:"home_score"`
+	AwayScore     int              `json:"away_score"`
+}
+
+type MatchTimelineDTO struct {
+	MatchID   uint            `json:"match_id"`
+	HomeTeam  TeamSimpleDTO   `json:"home_team"`
+	AwayTeam  TeamSimpleDTO   `json:"away_team"`
+	HomeScore int             `json:"home_score"`
+	AwayScore int             `json:"away_score"`
+	Events    []MatchEventDTO `json:"events"`
+}
+
+func toPlayerSimpleDTO(p *models.Player) PlayerSimpleDTO {
+	return PlayerSimpleDTO{
+		ID:           p.ID,
+		Name:         p.Name,
+		JerseyNumber: p.JerseyNumber,
+	}
+}
+
+func ToMatchTimelineDTO(m *models.Match, events []models.MatchEvent) MatchTimelineDTO {
+	homeScore := 0
+	awayScore := 0
+
+	list := make([]MatchEventDTO, 0, len(events))
+	for _, e := range events {
+		scoringTeam := uint(0)
+		switch e.Type {
+		case "GOL", "GOL_PENALTI":
+			scoringTeam = e.TeamID
+		case "GOL_BUNUH_DIRI":
+			scoringTeam = m.HomeTeamID
+			if e.TeamID == m.HomeTeamID {
+				scoringTeam = m.AwayTeamID
+			}
+		}
+
+		if scoringTeam == m.HomeTeamID {
+			homeScore++
+		} else if scoringTeam == m.AwayTeamID {
+			awayScore++
+		}
+
+		list = append(list, ToMatchEventDTO(&e, homeScore, awayScore))
+	}
+
+	return MatchTimelineDTO{
+		MatchID:   m.ID,
+		HomeTeam:  TeamSimpleDTO{ID: m.HomeTeam.ID, Name: m.HomeTeam.Name},
+		AwayTeam:  TeamSimpleDTO{ID: m.AwayTeam.ID, Name: m.AwayTeam.Name},
+		HomeScore: homeScore,
+		AwayScore: awayScore,
+		Events:    list,
+	}
+}
+
+func ToMatchEventDTO(e *models.MatchEvent, homeScore, awayScore int) MatchEventDTO {
+	var related *PlayerSimpleDTO
+	if e.RelatedPlayer != nil {
+		p := toPlayerSimpleDTO(e.RelatedPlayer)
+		related = &p
+	}
+
+	return MatchEventDTO{
+		ID:            e.ID,
+		Type:          e.Type,
+		Minute:        e.Minute,
+		Team:          TeamSimpleDTO{ID: e.Team.ID, Name: e.Team.Name},
+		Player:        toPlayerSimpleDTO(&e.Player),
+		RelatedPlayer: related,
+		GoalID:        e.GoalID,
+		Note:          e.Note,
+		HomeScore:     homeScore,
+		AwayScore:     awayScore,
+	}
+}
@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"football-backend/internal/models"
+)
+
+type MatchEventRevisionDTO struct {
+	ID        uint            `json:"id"`
+	EventID   *uint           `json:"event_id"`
+	GoalID    *uint           `json:"goal_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	Reason    string          `json:"reason"`
+	ChangedBy UserDTO         `json:"changed_by"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func rawSnapshot(s string) json.RawMessage {
+	if s == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(s)
+}
+
+func ToMatchEventRevisionDTO(r *models.MatchEventRevision) MatchEventRevisionDTO {
+	return MatchEventRevisionDTO{
+		ID:        r.ID,
+		EventID:   r.EventID,
+		GoalID:    r.GoalID,
+		Action:    r.Action,
+		Before:    rawSnapshot(r.Before),
+		After:     rawSnapshot(r.After),
+		Reason:    r.Reason,
+		ChangedBy: ToUserDTO(&r.ChangedBy),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func ToMatchEventRevisionDTOList(list []models.MatchEventRevision) []MatchEventRevisionDTO {
+	out := make([]MatchEventRevisionDTO, 0, len(list))
+	for i := range list {
+		out = append(out, ToMatchEventRevisionDTO(&list[i]))
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package dto
+
+import "football-backend/internal/models"
+
+type LineupPlayerDTO struct {
+	PlayerID     uint   `json:"player_id"`
+	Name         string `json:"name"`
+	Position     string `json:"position"`
+	JerseyNumber int    `json:"jersey_number"`
+	Captain      bool   `json:"captain"`
+}
+
+type MatchLineupDTO struct {
+	TeamID      uint              `json:"team_id"`
+	TeamName    string            `json:"team_name"`
+	Formation   string            `json:"formation"`
+	Captain     PlayerSimpleDTO   `json:"captain"`
+	Starters    []LineupPlayerDTO `json:"starters"`
+	Substitutes []LineupPlayerDTO `json:"substitutes"`
+}
+
+func ToMatchLineupDTO(l *models.MatchLineup) MatchLineupDTO {
+	starters := []LineupPlayerDTO{}
+	subs := []LineupPlayerDTO{}
+
+	for _, lp := range l.Players {
+		item := LineupPlayerDTO{
+			PlayerID:     lp.PlayerID,
+			Name:         lp.Player.Name,
+			Position:     lp.Player.Position,
+			JerseyNumber: lp.JerseyNumber,
+			Captain:      lp.PlayerID == l.CaptainPlayerID,
+		}
+		if lp.Starter {
+			starters = append(starters, item)
+		} else {
+			subs = append(subs, item)
+		}
+	}
+
+	return MatchLineupDTO{
+		TeamID:      l.TeamID,
+		TeamName:    l.Team.Name,
+		Formation:   l.Formation,
+		Captain:     toPlayerSimpleDTO(&l.Captain),
+		Starters:    starters,
+		Substitutes: subs,
+	}
+}
+
+func ToMatchLineupDTOList(list []models.MatchLineup) []MatchLineupDTO {
+	result := make([]MatchLineupDTO, 0, len(list))
+	for _, l := range list {
+		result = append(result, ToMatchLineupDTO(&l))
+	}
+	return result
+}
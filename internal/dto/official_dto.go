@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"time"
+
+	"football-backend/internal/models"
+)
+
+type OfficialDTO struct {
+	ID          uint    `json:"id"`
+	Name        string  `json:"name"`
+	Nationality string  `json:"nationality"`
+	BirthDate   *string `json:"birth_date"`
+}
+
+type MatchOfficialDTO struct {
+	Role     string      `json:"role"`
+	Official OfficialDTO `json:"official"`
+}
+
+type MatchOfficialsDTO struct {
+	MatchID   uint               `json:"match_id"`
+	Officials []MatchOfficialDTO `json:"officials"`
+}
+
+type OfficialStatDTO struct {
+	Official                OfficialDTO `json:"official" gorm:"-"`
+	Matches                 int         `json:"matches"`
+	MatchesAsReferee        int         `json:"matches_as_referee"`
+	MatchesAsAssistant      int         `json:"matches_as_assistant"`
+	MatchesAsFourthOfficial int         `json:"matches_as_fourth_official"`
+	YellowCards             int         `json:"yellow_cards"`
+	SecondYellowCards       int         `json:"second_yellow_cards"`
+	RedCards                int         `json:"red_cards"`
+	PenaltiesAwarded        int         `json:"penalties_awarded"`
+	CardsPerMatch           float64     `json:"cards_per_match" gorm:"-"`
+}
+
+func ToOfficialDTO(o *models.Official) OfficialDTO {
+	var birthDate *string
+	if o.BirthDate != nil {
+		d := o.BirthDate.Format(time.RFC3339)
+		birthDate = &d
+	}
+
+	return OfficialDTO{
+		ID:          o.ID,
+		Name:        o.Name,
+		Nationality: o.Nationality,
+		BirthDate:   birthDate,
+	}
+}
+
+func ToOfficialDTOList(list []models.Official) []OfficialDTO {
+	result := make([]OfficialDTO, 0, len(list))
+	for _, o := range list {
+		result = append(result, ToOfficialDTO(&o))
+	}
+	return result
+}
+
+func ToMatchOfficialsDTO(matchID uint, list []models.MatchOfficial) MatchOfficialsDTO {
+	officials := make([]MatchOfficialDTO, 0, len(list))
+	for _, mo := range list {
+		officials = append(officials, MatchOfficialDTO{
+			Role:     mo.Role,
+			Official: ToOfficialDTO(&mo.Official),
+		})
+	}
+	return MatchOfficialsDTO{MatchID: matchID, Officials: officials}
+}
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"time"
+
+	"football-backend/internal/models"
+)
+
+type PlayerInjuryDTO struct {
+	ID             uint            `json:"id"`
+	Player         PlayerSimpleDTO `json:"player"`
+	Type           string          `json:"type"`
+	Note           string          `json:"note"`
+	StartDate      string          `json:"start_date"`
+	ExpectedReturn string          `json:"expected_return"`
+	ReturnedAt     *string         `json:"returned_at"`
+}
+
+type CompetitionSimpleDTO struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type PlayerSuspensionDTO struct {
+	ID            uint                 `json:"id"`
+	Player        PlayerSimpleDTO      `json:"player"`
+	Competition   CompetitionSimpleDTO `json:"competition"`
+	SourceMatchID *uint                `json:"source_match_id"`
+	Reason        string               `json:"reason"`
+	Matches       int                  `json:"matches"`
+	Served        int                  `json:"served"`
+	Remaining     int                  `json:"remaining"`
+	StartsAt      string               `json:"starts_at"`
+	Note          string               `json:"note"`
+}
+
+type UnavailablePlayerDTO struct {
+	Player     PlayerSimpleDTO      `json:"player"`
+	Status     string               `json:"status"`
+	Injury     *PlayerInjuryDTO     `json:"injury,omitempty"`
+	Suspension *PlayerSuspensionDTO `json:"suspension,omitempty"`
+}
+
+type UpcomingMatchDTO struct {
+	ID            uint          `json:"id"`
+	MatchDateTime string        `json:"match_date_time"`
+	Venue         string        `json:"venue"`
+	Opponent      TeamSimpleDTO `json:"opponent"`
+}
+
+type TeamUnavailableDTO struct {
+	TeamID  uint                   `json:"team_id"`
+	Match   UpcomingMatchDTO       `json:"match"`
+	Players []UnavailablePlayerDTO `json:"players"`
+}
+
+func ToPlayerInjuryDTO(i *models.PlayerInjury) PlayerInjuryDTO {
+	var returnedAt *string
+	if i.ReturnedAt != nil {
+		t := i.ReturnedAt.Format(time.RFC3339)
+		returnedAt = &t
+	}
+
+	return PlayerInjuryDTO{
+		ID:             i.ID,
+		Player:         toPlayerSimpleDTO(&i.Player),
+		Type:           i.Type,
+		Note:           i.Note,
+		StartDate:      i.StartDate.Format(time.RFC3339),
+		ExpectedReturn: i.ExpectedReturn.Format(time.RFC3339),
+		ReturnedAt:     returnedAt,
+	}
+}
+
+func ToPlayerInjuryDTOList(list []models.PlayerInjury) []PlayerInjuryDTO {
+	result := make([]PlayerInjuryDTO, 0, len(list))
+	for _, i := range list {
+		result = append(result, ToPlayerInjuryDTO(&i))
+	}
+	return result
+}
+
+func ToPlayerSuspensionDTO(s *models.PlayerSuspension, served int) PlayerSuspensionDTO {
+	remaining := s.Matches - served
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return PlayerSuspensionDTO{
+		ID:            s.ID,
+		Player:        toPlayerSimpleDTO(&s.Player),
+		Competition:   CompetitionSimpleDTO{ID: s.Competition.ID, Name: s.Competition.Name},
+		SourceMatchID: s.SourceMatchID,
+		Reason:        s.Reason,
+		Matches:       s.Matches,
+		Served:        served,
+		Remaining:     remaining,
+		StartsAt:      s.StartsAt.Format(time.RFC3339),
+		Note:          s.Note,
+	}
+}
+
+func ToUpcomingMatchDTO(m *models.Match, teamID uint) UpcomingMatchDTO {
+	venue := "HOME"
+	opponent := m.AwayTeam
+	if m.AwayTeamID == teamID {
+		venue = "AWAY"
+		opponent = m.HomeTeam
+	}
+
+	return UpcomingMatchDTO{
+		ID:            m.ID,
+		MatchDateTime: m.MatchDateTime.Format(time.RFC3339),
+		Venue:         venue,
+		Opponent:      TeamSimpleDTO{ID: opponent.ID, Name: opponent.Name},
+	}
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"football-backend/internal/models"
+)
+
+type PlayerContractDTO struct {
+	ID           uint            `json:"id"`
+	Player       PlayerSimpleDTO `json:"player"`
+	Team         TeamSimpleDTO   `json:"team"`
+	StartDate    string          `json:"start_date"`
+	EndDate      string          `json:"end_date"`
+	SquadRole    string          `json:"squad_role"`
+	JerseyNumber int             `json:"jersey_number"`
+	TerminatedAt *string         `json:"terminated_at"`
+}
+
+func ToPlayerContractDTO(c *models.PlayerContract) PlayerContractDTO {
+	var terminatedAt *string
+	if c.TerminatedAt != nil {
+		t := c.TerminatedAt.Format(time.RFC3339)
+		terminatedAt = &t
+	}
+
+	return PlayerContractDTO{
+		ID:           c.ID,
+		Player:       toPlayerSimpleDTO(&c.Player),
+		Team:         TeamSimpleDTO{ID: c.Team.ID, Name: c.Team.Name},
+		StartDate:    c.StartDate.Format(time.RFC3339),
+		EndDate:      c.EndDate.Format(time.RFC3339),
+		SquadRole:    c.SquadRole,
+		JerseyNumber: c.JerseyNumber,
+		TerminatedAt: terminatedAt,
+	}
+}
+
+func ToPlayerContractDTOList(list []models.PlayerContract) []PlayerContractDTO {
+	result := make([]PlayerContractDTO, 0, len(list))
+	for _, c := range list {
+		result = append(result, ToPlayerContractDTO(&c))
+	}
+	return result
+}
@@ -14,6 +14,7 @@ type PlayerDTO struct {
 	WeightKG     int           `json:"weight"`
 	Position     string        `json:"position"`
 	JerseyNumber int           `json:"jersey_number"`
+	Nationality  string        `json:"nationality"`
 	Team         TeamSimpleDTO `json:"team"`
 }
 
@@ -25,6 +26,7 @@ func ToPlayerDTO(p *models.Player) PlayerDTO {
 		WeightKG:     p.WeightKG,
 		Position:     p.Position,
 		JerseyNumber: p.JerseyNumber,
+		Nationality:  p.Nationality,
 		Team: TeamSimpleDTO{
 			ID:   p.Team.ID,
 			Name: p.Team.Name,
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"football-backend/internal/models"
+)
+
+type PlayerLoanDTO struct {
+	ID                 uint            `json:"id"`
+	Player             PlayerSimpleDTO `json:"player"`
+	ParentTeam         TeamSimpleDTO   `json:"parent_team"`
+	LoanTeam           TeamSimpleDTO   `json:"loan_team"`
+	StartDate          string          `json:"start_date"`
+	EndDate            string          `json:"end_date"`
+	OptionToBuy        bool            `json:"option_to_buy"`
+	ParentJerseyNumber int             `json:"parent_jersey_number"`
+	LoanJerseyNumber   int             `json:"loan_jersey_number"`
+	WindowOverride     bool            `json:"window_override"`
+	Status             string          `json:"status"`
+	FailureReason      string          `json:"failure_reason,omitempty"`
+}
+
+func ToPlayerLoanDTO(l *models.PlayerLoan) PlayerLoanDTO {
+	return PlayerLoanDTO{
+		ID:                 l.ID,
+		Player:             toPlayerSimpleDTO(&l.Player),
+		ParentTeam:         TeamSimpleDTO{ID: l.ParentTeam.ID, Name: l.ParentTeam.Name},
+		LoanTeam:           TeamSimpleDTO{ID: l.LoanTeam.ID, Name: l.LoanTeam.Name},
+		StartDate:          l.StartDate.Format(time.RFC3339),
+		EndDate:            l.EndDate.Format(time.RFC3339),
+		OptionToBuy:        l.OptionToBuy,
+		ParentJerseyNumber: l.ParentJerseyNumber,
+		LoanJerseyNumber:   l.LoanJerseyNumber,
+		WindowOverride:     l.WindowOverride,
+		Status:             l.Status,
+		FailureReason:      l.FailureReason,
+	}
+}
+
+func ToPlayerLoanDTOList(list []models.PlayerLoan) []PlayerLoanDTO {
+	result := make([]PlayerLoanDTO, 0, len(list))
+	for _, l := range list {
+		result = append(result, ToPlayerLoanDTO(&l))
+	}
+	return result
+}
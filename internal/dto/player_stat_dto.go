@@ -0,0 +1,17 @@
+package dto
+
+type PlayerStatDTO struct {
+	PlayerID        uint    `json:"player_id"`
+	PlayerName      string  `json:"player_name"`
+	TeamID          uint    `json:"team_id"`
+	TeamName        string  `json:"team_name"`
+	Appearances     int     `json:"appearances"`
+	Minutes         int     `json:"minutes"`
+	Goals           int     `json:"goals"`
+	Assists         int     `json:"assists"`
+	PenaltyGoals    int     `json:"penalty_goals"`
+	PenaltiesMissed int     `json:"penalties_missed"`
+	YellowCards     int     `json:"yellow_cards"`
+	RedCards        int     `json:"red_cards"`
+	GoalsPer90      float64 `json:"goals_per_90" gorm:"column:goals_per_90"`
+}
@@ -1,6 +1,7 @@
 package dto
 
 type StandingDTO struct {
+	Position       int    `json:"position"`
 	TeamID         uint   `json:"team_id"`
 	TeamName       string `json:"team_name"`
 	Played         int    `json:"played"`
@@ -11,4 +12,32 @@ type StandingDTO struct {
 	GoalsAgainst   int    `json:"goals_against"`
 	GoalDifference int    `json:"goal_difference"`
 	Points         int    `json:"points"`
+	Form           string `json:"form"`
+	PointsDeducted int    `json:"points_deducted"`
+	FairPlayPoints int    `json:"fair_play_points"`
+	Tiebreaker     string `json:"tiebreaker,omitempty"`
+}
+
+type GroupStandingRowDTO struct {
+	StandingDTO
+	Qualification string `json:"qualification"`
+}
+
+type GroupTableDTO struct {
+	GroupID   uint                  `json:"group_id"`
+	GroupName string                `json:"group_name"`
+	Table     []GroupStandingRowDTO `json:"table"`
+}
+
+type MatchdayPositionDTO struct {
+	Matchday int    `json:"matchday"`
+	Date     string `json:"date"`
+	Position int    `json:"position"`
+	Points   int    `json:"points"`
+}
+
+type PositionHistoryDTO struct {
+	TeamID    uint                  `json:"team_id"`
+	TeamName  string                `json:"team_name"`
+	Positions []MatchdayPositionDTO `json:"positions"`
 }
@@ -9,6 +9,9 @@ type TeamDTO struct {
 	YearFounded int    `json:"year_founded"`
 	Address     string `json:"address"`
 	City        string `json:"city"`
+
+	HomeVenueID *uint           `json:"home_venue_id"`
+	HomeVenue   *VenueSimpleDTO `json:"home_venue,omitempty"`
 }
 
 func ToTeamDTO(t *models.Team) TeamDTO {
@@ -19,6 +22,9 @@ func ToTeamDTO(t *models.Team) TeamDTO {
 		YearFounded: t.YearFounded,
 		Address:     t.Address,
 		City:        t.City,
+
+		HomeVenueID: t.HomeVenueID,
+		HomeVenue:   ToVenueSimpleDTO(t.HomeVenue),
 	}
 }
 
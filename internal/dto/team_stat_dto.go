@@ -0,0 +1,52 @@
+package dto
+
+type TeamMatchResultDTO struct {
+	MatchID      uint          `json:"match_id"`
+	MatchDate    string        `json:"match_date"`
+	Venue        string        `json:"venue"`
+	Opponent     TeamSimpleDTO `json:"opponent"`
+	GoalsFor     int           `json:"goals_for"`
+	GoalsAgainst int           `json:"goals_against"`
+}
+
+type StreakDTO struct {
+	Result string `json:"result"`
+	Length int    `json:"length"`
+}
+
+type TeamStatDTO struct {
+	Team           TeamSimpleDTO `json:"team"`
+	Played         int           `json:"played"`
+	Wins           int           `json:"wins"`
+	Draws          int           `json:"draws"`
+	Losses         int           `json:"losses"`
+	GoalsFor       int           `json:"goals_for"`
+	GoalsAgainst   int           `json:"goals_against"`
+	GoalDifference int           `json:"goal_difference"`
+	CleanSheets    int           `json:"clean_sheets"`
+	FailedToScore  int           `json:"failed_to_score"`
+
+	BiggestWin  *TeamMatchResultDTO `json:"biggest_win"`
+	BiggestLoss *TeamMatchResultDTO `json:"biggest_loss"`
+
+	CurrentStreak         *StreakDTO `json:"current_streak"`
+	LongestWinStreak      int        `json:"longest_win_streak"`
+	LongestUnbeatenStreak int        `json:"longest_unbeaten_streak"`
+	LongestLosingStreak   int        `json:"longest_losing_streak"`
+}
+
+type HeadToHeadRecordDTO struct {
+	Played     int `json:"played"`
+	TeamAWins  int `json:"team_a_wins"`
+	TeamBWins  int `json:"team_b_wins"`
+	Draws      int `json:"draws"`
+	TeamAGoals int `json:"team_a_goals"`
+	TeamBGoals int `json:"team_b_goals"`
+}
+
+type HeadToHeadDTO struct {
+	TeamA   TeamSimpleDTO       `json:"team_a"`
+	TeamB   TeamSimpleDTO       `json:"team_b"`
+	Record  HeadToHeadRecordDTO `json:"record"`
+	Matches []MatchDTO          `json:"matches"`
+}
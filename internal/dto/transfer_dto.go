@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"time"
+
+	"football-backend/internal/models"
+)
+
+type TransferWindowDTO struct {
+	ID       uint   `json:"id"`
+	SeasonID uint   `json:"season_id"`
+	Name     string `json:"name"`
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+}
+
+type PlayerTransferDTO struct {
+	ID             uint               `json:"id"`
+	Player         PlayerSimpleDTO    `json:"player"`
+	OldTeam        TeamSimpleDTO      `json:"old_team"`
+	NewTeam        TeamSimpleDTO      `json:"new_team"`
+	JerseyNumber   int                `json:"jersey_number"`
+	Type           string             `json:"type"`
+	LoanID         *uint              `json:"loan_id"`
+	TransferWindow *TransferWindowDTO `json:"transfer_window"`
+	WindowOverride bool               `json:"window_override"`
+	TransferredAt  string             `json:"transferred_at"`
+}
+
+type TeamTransfersDTO struct {
+	Team     TeamSimpleDTO       `json:"team"`
+	Incoming []PlayerTransferDTO `json:"incoming"`
+	Outgoing []PlayerTransferDTO `json:"outgoing"`
+}
+
+func ToTransferWindowDTO(w *models.TransferWindow) TransferWindowDTO {
+	return TransferWindowDTO{
+		ID:       w.ID,
+		SeasonID: w.SeasonID,
+		Name:     w.Name,
+		OpensAt:  w.OpensAt.Format(time.RFC3339),
+		ClosesAt: w.ClosesAt.Format(time.RFC3339),
+	}
+}
+
+func ToTransferWindowDTOList(list []models.TransferWindow) []TransferWindowDTO {
+	result := make([]TransferWindowDTO, 0, len(list))
+	for _, w := range list {
+		result = append(result, ToTransferWindowDTO(&w))
+	}
+	return result
+}
+
+func ToPlayerTransferDTO(t *models.PlayerTransfer) PlayerTransferDTO {
+	var window *TransferWindowDTO
+	if t.TransferWindow != nil {
+		w := ToTransferWindowDTO(t.TransferWindow)
+		window = &w
+	}
+
+	return PlayerTransferDTO{
+		ID:             t.ID,
+		Player:         toPlayerSimpleDTO(&t.Player),
+		OldTeam:        TeamSimpleDTO{ID: t.OldTeam.ID, Name: t.OldTeam.Name},
+		NewTeam:        TeamSimpleDTO{ID: t.NewTeam.ID, Name: t.NewTeam.Name},
+		JerseyNumber:   t.JerseyNumber,
+		Type:           t.Type,
+		LoanID:         t.LoanID,
+		TransferWindow: window,
+		WindowOverride: t.WindowOverride,
+		TransferredAt:  t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToPlayerTransferDTOList(list []models.PlayerTransfer) []PlayerTransferDTO {
+	result := make([]PlayerTransferDTO, 0, len(list))
+	for _, t := range list {
+		result = append(result, ToPlayerTransferDTO(&t))
+	}
+	return result
+}
+
+func ToTeamTransfersDTO(team *models.Team, list []models.PlayerTransfer) TeamTransfersDTO {
+	result := TeamTransfersDTO{
+		Team:     TeamSimpleDTO{ID: team.ID, Name: team.Name},
+		Incoming: []PlayerTransferDTO{},
+		Outgoing: []PlayerTransferDTO{},
+	}
+
+	for _, t := range list {
+		if t.NewTeamID == team.ID {
+			result.Incoming = append(result.Incoming, ToPlayerTransferDTO(&t))
+		}
+		if t.OldTeamID == team.ID {
+			result.Outgoing = append(result.Outgoing, ToPlayerTransferDTO(&t))
+		}
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"football-backend/internal/models"
+)
+
+type UserSessionDTO struct {
+	ID         uint   `json:"id"`
+	DeviceName string `json:"device_name"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	CreatedAt  string `json:"created_at"`
+	LastUsedAt string `json:"last_used_at"`
+	ExpiresAt  string `json:"expires_at"`
+	Current    bool   `json:"current"`
+}
+
+func ToUserSessionDTO(s *models.UserSession, currentID uint) UserSessionDTO {
+	return UserSessionDTO{
+		ID:         s.ID,
+		DeviceName: s.DeviceName,
+		IPAddress:  s.IPAddress,
+		UserAgent:  s.UserAgent,
+		CreatedAt:  s.CreatedAt.Format(time.RFC3339),
+		LastUsedAt: s.LastUsedAt.Format(time.RFC3339),
+		ExpiresAt:  s.ExpiresAt.Format(time.RFC3339),
+		Current:    s.ID == currentID,
+	}
+}
+
+func ToUserSessionDTOList(list []models.UserSession, currentID uint) []UserSessionDTO {
+	result := make([]UserSessionDTO, 0, len(list))
+	for _, s := range list {
+		result = append(result, ToUserSessionDTO(&s, currentID))
+	}
+	return result
+}
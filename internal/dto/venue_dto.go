@@ -0,0 +1,48 @@
+package dto
+
+import "football-backend/internal/models"
+
+type VenueDTO struct {
+	ID        uint     `json:"id"`
+	Name      string   `json:"name"`
+	Address   string   `json:"address"`
+	City      string   `json:"city"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	Capacity  int      `json:"capacity"`
+	Surface   string   `json:"surface"`
+}
+
+type VenueSimpleDTO struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	City string `json:"city"`
+}
+
+func ToVenueDTO(v *models.Venue) VenueDTO {
+	return VenueDTO{
+		ID:        v.ID,
+		Name:      v.Name,
+		Address:   v.Address,
+		City:      v.City,
+		Latitude:  v.Latitude,
+		Longitude: v.Longitude,
+		Capacity:  v.Capacity,
+		Surface:   v.Surface,
+	}
+}
+
+func ToVenueDTOList(list []models.Venue) []VenueDTO {
+	result := make([]VenueDTO, 0, len(list))
+	for _, v := range list {
+		result = append(result, ToVenueDTO(&v))
+	}
+	return result
+}
+
+func ToVenueSimpleDTO(v *models.Venue) *VenueSimpleDTO {
+	if v == nil {
+		return nil
+	}
+	return &VenueSimpleDTO{ID: v.ID, Name: v.Name, City: v.City}
+}
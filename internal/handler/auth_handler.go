@@ -4,17 +4,20 @@ import (
 	"football-backend/internal/dto"
 	"football-backend/internal/response"
 	"football-backend/internal/service"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type AuthHandler struct {
-	service service.AuthService
+	service   service.AuthService
+	twoFactor service.TwoFactorService
 }
 
-func NewAuthHandler(s service.AuthService) *AuthHandler {
-	return &AuthHandler{service: s}
+func NewAuthHandler(s service.AuthService, tf service.TwoFactorService) *AuthHandler {
+	return &AuthHandler{service: s, twoFactor: tf}
 }
 
 func (h *AuthHandler) Register(c *gin.Context) {
@@ -39,8 +42,9 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 func (h *AuthHandler) Login(c *gin.Context) {
 	var input struct {
-		Username string `json:"username" binding:"required"`
-		Password string `json:"password" binding:"required"`
+		Username   string `json:"username" binding:"required"`
+		Password   string `json:"password" binding:"required"`
+		DeviceName string `json:"device_name"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -48,17 +52,60 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	access, refresh, user, err := h.service.Login(input.Username, input.Password)
+	tokens, err := h.service.Login(input.Username, input.Password, clientInfo(c, input.DeviceName))
 	if err != nil {
 		response.FromError(c, err)
 		return
 	}
 
-	response.Success(c, 200, "Login berhasil", gin.H{
-		"access_token":  access,
-		"refresh_token": refresh,
-		"user":          dto.ToUserDTO(user),
-	})
+	if tokens.ChallengeToken != "" {
+		response.Success(c, 200, "Verifikasi 2FA diperlukan", gin.H{
+			"two_factor_required": true,
+			"challenge_token":     tokens.ChallengeToken,
+			"expires_at":          tokens.ChallengeExpiresAt.Format(time.RFC3339),
+		})
+		return
+	}
+
+	response.Success(c, 200, "Login berhasil", tokenResponse(tokens))
+}
+
+func (h *AuthHandler) LoginTwoFactor(c *gin.Context) {
+	var input struct {
+		ChallengeToken string `json:"challenge_token" binding:"required"`
+		Code           string `json:"code" binding:"required"`
+		DeviceName     string `json:"device_name"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	tokens, err := h.service.VerifyTwoFactor(input.ChallengeToken, input.Code, clientInfo(c, input.DeviceName))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Login berhasil", tokenResponse(tokens))
+}
+
+func clientInfo(c *gin.Context, deviceName string) service.ClientInfo {
+	return service.ClientInfo{
+		DeviceName: strings.TrimSpace(deviceName),
+		IPAddress:  c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+	}
+}
+
+func tokenResponse(tokens *service.AuthTokens) gin.H {
+	return gin.H{
+		"access_token":  tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"session_id":    tokens.SessionID,
+		"user":          dto.ToUserDTO(tokens.User),
+	}
 }
 
 func (h *AuthHandler) Me(c *gin.Context) {
@@ -89,17 +136,13 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		return
 	}
 
-	access, newRefresh, user, err := h.service.Refresh(body.RefreshToken)
+	tokens, err := h.service.Refresh(body.RefreshToken, clientInfo(c, ""))
 	if err != nil {
 		response.FromError(c, err)
 		return
 	}
 
-	response.Success(c, 200, "Token diperbarui", gin.H{
-		"access_token":  access,
-		"refresh_token": newRefresh,
-		"user":          dto.ToUserDTO(user),
-	})
+	response.Success(c, 200, "Token diperbarui", tokenResponse(tokens))
 }
 
 func (h *AuthHandler) Logout(c *gin.Context) {
@@ -119,3 +162,213 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 
 	response.Success(c, 200, "Berhasil logout", nil)
 }
+
+func (h *AuthHandler) Sessions(c *gin.Context) {
+	uid, _ := c.Get("user_id")
+	sid, _ := c.Get("session_id")
+
+	list, err := h.service.Sessions(uid.(uint))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Daftar sesi berhasil diambil", dto.ToUserSessionDTOList(list, sid.(uint)))
+}
+
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	uid, _ := c.Get("user_id")
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	if err := h.service.RevokeSession(uid.(uint), uint(id)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Sesi berhasil dicabut", nil)
+}
+
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	uid, _ := c.Get("user_id")
+	sid, _ := c.Get("session_id")
+
+	revoked, err := h.service.RevokeOtherSessions(uid.(uint), sid.(uint))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Sesi lain berhasil dicabut", gin.H{"revoked": revoked})
+}
+
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var input struct {
+		CurrentPassword string `json:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+	sid, _ := c.Get("session_id")
+
+	if err := h.service.ChangePassword(uid.(uint), sid.(uint), input.CurrentPassword, input.NewPassword); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Password berhasil diubah, sesi lain telah dicabut", nil)
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var input struct {
+		Username string `json:"username" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	if err := h.service.RequestPasswordReset(input.Username, clientInfo(c, "")); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Jika username terdaftar, token reset password telah dikirim", nil)
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var input struct {
+		Token       string `json:"token" binding:"required"`
+		NewPassword string `json:"new_password" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	if err := h.service.ResetPassword(input.Token, input.NewPassword); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Password berhasil direset, silakan login ulang", nil)
+}
+
+func (h *AuthHandler) LiveTicket(c *gin.Context) {
+	uid, _ := c.Get("user_id")
+	sid, _ := c.Get("session_id")
+
+	ticket, expiresAt, err := h.service.LiveTicket(uid.(uint), sid.(uint))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Tiket live berhasil dibuat", gin.H{
+		"ticket":     ticket,
+		"expires_at": expiresAt,
+	})
+}
+
+func (h *AuthHandler) TwoFactorStatus(c *gin.Context) {
+	uid, _ := c.Get("user_id")
+
+	status, err := h.twoFactor.Status(uid.(uint))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Status 2FA berhasil diambil", gin.H{
+		"enabled":                  status.Enabled,
+		"required":                 status.Required,
+		"recovery_codes_remaining": status.RecoveryCodesRemaining,
+	})
+}
+
+func (h *AuthHandler) EnrollTwoFactor(c *gin.Context) {
+	uid, _ := c.Get("user_id")
+
+	enrollment, err := h.twoFactor.Enroll(uid.(uint))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Scan provisioning URI lalu konfirmasi dengan kode 2FA", gin.H{
+		"secret":           enrollment.Secret,
+		"provisioning_uri": enrollment.ProvisioningURI,
+	})
+}
+
+func (h *AuthHandler) ConfirmTwoFactor(c *gin.Context) {
+	var input struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+	sid, _ := c.Get("session_id")
+	codes, err := h.twoFactor.Confirm(uid.(uint), sid.(uint), input.Code)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "2FA berhasil diaktifkan, sesi lain telah dicabut. Simpan recovery code di tempat aman", gin.H{"recovery_codes": codes})
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var input struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(uid.(uint), input.Code)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Recovery code baru berhasil dibuat", gin.H{"recovery_codes": codes})
+}
+
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var input struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+	if err := h.twoFactor.Disable(uid.(uint), input.Password, input.Code); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "2FA berhasil dinonaktifkan", nil)
+}
+
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(200, h.service.JWKS())
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BracketHandler struct {
+	service service.BracketService
+}
+
+func NewBracketHandler(s service.BracketService) *BracketHandler {
+	return &BracketHandler{s}
+}
+
+func (h *BracketHandler) Create(c *gin.Context) {
+	var input struct {
+		SeasonID          uint   `json:"season_id" binding:"required"`
+		Name              string `json:"name" binding:"required"`
+		TeamIDs           []uint `json:"team_ids" binding:"required"`
+		Seeded            bool   `json:"seeded"`
+		TwoLegged         bool   `json:"two_legged"`
+		AwayGoalsRule     bool   `json:"away_goals_rule"`
+		ExtraTime         *bool  `json:"extra_time"`
+		StartDate         string `json:"start_date" binding:"required"`
+		RoundIntervalDays int    `json:"round_interval_days"`
+		LegIntervalDays   int    `json:"leg_interval_days"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, input.StartDate)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	if input.RoundIntervalDays == 0 {
+		input.RoundIntervalDays = 14
+	}
+	if input.TwoLegged && input.LegIntervalDays == 0 {
+		input.LegIntervalDays = 7
+	}
+	extraTime := true
+	if input.ExtraTime != nil {
+		extraTime = *input.ExtraTime
+	}
+
+	b, err := h.service.Create(service.BracketOptions{
+		SeasonID:          input.SeasonID,
+		Name:              input.Name,
+		TeamIDs:           input.TeamIDs,
+		Seeded:            input.Seeded,
+		TwoLegged:         input.TwoLegged,
+		AwayGoalsRule:     input.AwayGoalsRule,
+		ExtraTime:         extraTime,
+		StartDate:         start,
+		RoundIntervalDays: input.RoundIntervalDays,
+		LegIntervalDays:   input.LegIntervalDays,
+	})
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Bracket berhasil dibuat", dto.ToBracketDTO(b))
+}
+
+func (h *BracketHandler) GetByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	b, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data bracket berhasil diambil", dto.ToBracketDTO(b))
+}
+
+func (h *BracketHandler) GetBySeason(c *gin.Context) {
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+
+	list, err := h.service.GetBySeason(uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	result := make([]gin.H, 0, len(list))
+	for _, b := range list {
+		result = append(result, gin.H{
+			"id":         b.ID,
+			"name":       b.Name,
+			"size":       b.Size,
+			"two_legged": b.TwoLegged,
+		})
+	}
+
+	response.Success(c, 200, "Data bracket berhasil diambil", result)
+}
+
+func (h *BracketHandler) RecordPenalties(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		Kicks []struct {
+			TeamID   uint `json:"team_id" binding:"required"`
+			PlayerID uint `json:"player_id" binding:"required"`
+			Order    int  `json:"order" binding:"required"`
+			Scored   bool `json:"scored"`
+		} `json:"kicks" binding:"required"`
+		ExtraTime bool `json:"extra_time"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	kicks := make([]models.PenaltyKick, 0, len(input.Kicks))
+	for _, k := range input.Kicks {
+		kicks = append(kicks, models.PenaltyKick{
+			TeamID:    k.TeamID,
+			PlayerID:  k.PlayerID,
+			KickOrder: k.Order,
+			Scored:    k.Scored,
+		})
+	}
+
+	if err := h.service.RecordPenalties(uint(id), kicks, input.ExtraTime); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Adu penalti berhasil disimpan", nil)
+}
@@ -0,0 +1,599 @@
+package handler
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"football-backend/internal/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompetitionHandler struct {
+	service      service.CompetitionService
+	matchService service.MatchService
+	goalService  service.GoalService
+}
+
+func NewCompetitionHandler(s service.CompetitionService, m service.MatchService, g service.GoalService) *CompetitionHandler {
+	return &CompetitionHandler{s, m, g}
+}
+
+func (h *CompetitionHandler) Create(c *gin.Context) {
+	var input struct {
+		Name    string `json:"name" binding:"required"`
+		Type    string `json:"type"`
+		Country string `json:"country"`
+		LogoURL string `json:"logo_url"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	comp := models.Competition{
+		Name:    input.Name,
+		Type:    input.Type,
+		Country: input.Country,
+		LogoURL: input.LogoURL,
+	}
+
+	if err := h.service.Create(&comp); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Kompetisi berhasil dibuat", dto.ToCompetitionDTO(&comp))
+}
+
+func (h *CompetitionHandler) GetAll(c *gin.Context) {
+	q := utils.ParseQuery(c)
+
+	result, err := h.service.GetList(q)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	items := result["items"].([]models.Competition)
+	result["items"] = dto.ToCompetitionDTOList(items)
+
+	response.Success(c, 200, "Data kompetisi berhasil diambil", result)
+}
+
+func (h *CompetitionHandler) GetByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	comp, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data kompetisi berhasil diambil", dto.ToCompetitionDTO(comp))
+}
+
+func (h *CompetitionHandler) Update(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	comp, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	var input struct {
+		Name    *string `json:"name"`
+		Type    *string `json:"type"`
+		Country *string `json:"country"`
+		LogoURL *string `json:"logo_url"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	if input.Name != nil {
+		comp.Name = *input.Name
+	}
+	if input.Type != nil {
+		comp.Type = *input.Type
+	}
+	if input.Country != nil {
+		comp.Country = *input.Country
+	}
+	if input.LogoURL != nil {
+		comp.LogoURL = *input.LogoURL
+	}
+
+	if err := h.service.Update(comp); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Kompetisi berhasil diperbarui", dto.ToCompetitionDTO(comp))
+}
+
+func (h *CompetitionHandler) Delete(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Kompetisi berhasil dihapus", nil)
+}
+
+func (h *CompetitionHandler) CreateSeason(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		Name      string `json:"name" binding:"required"`
+		StartDate string `json:"start_date" binding:"required"`
+		EndDate   string `json:"end_date" binding:"required"`
+		IsCurrent bool   `json:"is_current"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	start, err := time.Parse(time.DateOnly, input.StartDate)
+	if err != nil {
+		response.Error(c, 400, "Format start_date harus YYYY-MM-DD")
+		return
+	}
+	end, err := time.Parse(time.DateOnly, input.EndDate)
+	if err != nil {
+		response.Error(c, 400, "Format end_date harus YYYY-MM-DD")
+		return
+	}
+
+	season := models.Season{
+		CompetitionID: uint(id),
+		Name:          input.Name,
+		StartDate:     start,
+		EndDate:       end,
+		IsCurrent:     input.IsCurrent,
+	}
+
+	if err := h.service.CreateSeason(&season); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Musim berhasil dibuat", dto.ToSeasonDTO(&season))
+}
+
+func (h *CompetitionHandler) GetSeasons(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.service.GetSeasons(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data musim berhasil diambil", dto.ToSeasonDTOList(list))
+}
+
+func (h *CompetitionHandler) GetSeason(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	season, err := h.service.GetSeason(uint(id), uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data musim berhasil diambil", dto.ToSeasonDTO(season))
+}
+
+func (h *CompetitionHandler) UpdateSeason(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	season, err := h.service.GetSeason(uint(id), uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	var input struct {
+		Name      *string `json:"name"`
+		StartDate *string `json:"start_date"`
+		EndDate   *string `json:"end_date"`
+		IsCurrent *bool   `json:"is_current"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	if input.Name != nil {
+		season.Name = *input.Name
+	}
+	if input.StartDate != nil {
+		start, err := time.Parse(time.DateOnly, *input.StartDate)
+		if err != nil {
+			response.Error(c, 400, "Format start_date harus YYYY-MM-DD")
+			return
+		}
+		season.StartDate = start
+	}
+	if input.EndDate != nil {
+		end, err := time.Parse(time.DateOnly, *input.EndDate)
+		if err != nil {
+			response.Error(c, 400, "Format end_date harus YYYY-MM-DD")
+			return
+		}
+		season.EndDate = end
+	}
+	if input.IsCurrent != nil {
+		season.IsCurrent = *input.IsCurrent
+	}
+
+	if err := h.service.UpdateSeason(season); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Musim berhasil diperbarui", dto.ToSeasonDTO(season))
+}
+
+func (h *CompetitionHandler) DeleteSeason(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	if err := h.service.DeleteSeason(uint(id), uint(seasonID)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Musim berhasil dihapus", nil)
+}
+
+func (h *CompetitionHandler) SeasonStanding(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	if _, err := h.service.GetSeason(uint(id), uint(seasonID)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	venue := strings.ToUpper(c.Query("venue"))
+
+	data, err := h.matchService.LeagueStanding(uint(seasonID), venue)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Standing berhasil diambil", data)
+}
+
+func (h *CompetitionHandler) SeasonPositionHistory(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	if _, err := h.service.GetSeason(uint(id), uint(seasonID)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	data, err := h.matchService.PositionHistory(uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Riwayat posisi berhasil diambil", data)
+}
+
+func (h *CompetitionHandler) SeasonTopScorers(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	if _, err := h.service.GetSeason(uint(id), uint(seasonID)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	list, err := h.goalService.TopScorers(uint(seasonID), 10)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Top skor berhasil diambil", list)
+}
+
+func (h *CompetitionHandler) GetStandingRule(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	rule, err := h.service.GetStandingRule(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Aturan klasemen berhasil diambil", dto.ToStandingRuleDTO(rule))
+}
+
+func (h *CompetitionHandler) UpdateStandingRule(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	rule, err := h.service.GetStandingRule(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	var input struct {
+		PointsWin   *int     `json:"points_win"`
+		PointsDraw  *int     `json:"points_draw"`
+		PointsLoss  *int     `json:"points_loss"`
+		Tiebreakers []string `json:"tiebreakers"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	if input.PointsWin != nil {
+		rule.PointsWin = *input.PointsWin
+	}
+	if input.PointsDraw != nil {
+		rule.PointsDraw = *input.PointsDraw
+	}
+	if input.PointsLoss != nil {
+		rule.PointsLoss = *input.PointsLoss
+	}
+	if input.Tiebreakers != nil {
+		rule.Tiebreakers = strings.Join(input.Tiebreakers, ",")
+	}
+
+	if err := h.service.UpdateStandingRule(rule); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Aturan klasemen berhasil diperbarui", dto.ToStandingRuleDTO(rule))
+}
+
+func (h *CompetitionHandler) GetSquadRule(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	rule, err := h.service.GetSquadRule(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Aturan skuad berhasil diambil", dto.ToSquadRuleDTO(rule))
+}
+
+func (h *CompetitionHandler) UpdateSquadRule(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	rule, err := h.service.GetSquadRule(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	var input struct {
+		MaxSquadSize      *int `json:"max_squad_size"`
+		MaxForeignPlayers *int `json:"max_foreign_players"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	if input.MaxSquadSize != nil {
+		rule.MaxSquadSize = *input.MaxSquadSize
+	}
+	if input.MaxForeignPlayers != nil {
+		rule.MaxForeignPlayers = *input.MaxForeignPlayers
+	}
+
+	if err := h.service.UpdateSquadRule(rule); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Aturan skuad berhasil diperbarui", dto.ToSquadRuleDTO(rule))
+}
+
+func (h *CompetitionHandler) GetDisciplineRule(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	rule, err := h.service.GetDisciplineRule(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Aturan disiplin berhasil diambil", dto.ToDisciplineRuleDTO(rule))
+}
+
+func (h *CompetitionHandler) UpdateDisciplineRule(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	rule, err := h.service.GetDisciplineRule(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	var input struct {
+		YellowCardThreshold *int `json:"yellow_card_threshold"`
+		YellowCardBan       *int `json:"yellow_card_ban"`
+		SecondYellowBan     *int `json:"second_yellow_ban"`
+		RedCardBan          *int `json:"red_card_ban"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	if input.YellowCardThreshold != nil {
+		rule.YellowCardThreshold = *input.YellowCardThreshold
+	}
+	if input.YellowCardBan != nil {
+		rule.YellowCardBan = *input.YellowCardBan
+	}
+	if input.SecondYellowBan != nil {
+		rule.SecondYellowBan = *input.SecondYellowBan
+	}
+	if input.RedCardBan != nil {
+		rule.RedCardBan = *input.RedCardBan
+	}
+
+	if err := h.service.UpdateDisciplineRule(rule); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Aturan disiplin berhasil diperbarui", dto.ToDisciplineRuleDTO(rule))
+}
+
+func (h *CompetitionHandler) AddSanction(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	var input struct {
+		TeamID         uint   `json:"team_id" binding:"required"`
+		PointsDeducted int    `json:"points_deducted"`
+		FairPlayPoints int    `json:"fair_play_points"`
+		Reason         string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	sanction := models.TeamSanction{
+		SeasonID:       uint(seasonID),
+		TeamID:         input.TeamID,
+		PointsDeducted: input.PointsDeducted,
+		FairPlayPoints: input.FairPlayPoints,
+		Reason:         input.Reason,
+	}
+
+	if err := h.service.AddSanction(uint(id), &sanction); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Sanksi berhasil ditambahkan", nil)
+}
+
+func (h *CompetitionHandler) GetSanctions(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	list, err := h.service.GetSanctions(uint(id), uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data sanksi berhasil diambil", dto.ToTeamSanctionDTOList(list))
+}
+
+func (h *CompetitionHandler) DeleteSanction(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+	sanctionID, _ := strconv.Atoi(c.Param("sanction_id"))
+
+	if err := h.service.DeleteSanction(uint(id), uint(seasonID), uint(sanctionID)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Sanksi berhasil dihapus", nil)
+}
+
+func (h *CompetitionHandler) AddTransferWindow(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	var input struct {
+		Name     string `json:"name" binding:"required"`
+		OpensAt  string `json:"opens_at" binding:"required"`
+		ClosesAt string `json:"closes_at" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	opensAt, err := time.Parse(time.RFC3339, input.OpensAt)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+	closesAt, err := time.Parse(time.RFC3339, input.ClosesAt)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	window := models.TransferWindow{
+		SeasonID: uint(seasonID),
+		Name:     input.Name,
+		OpensAt:  opensAt,
+		ClosesAt: closesAt,
+	}
+
+	if err := h.service.AddTransferWindow(uint(id), &window); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Jendela transfer berhasil ditambahkan", dto.ToTransferWindowDTO(&window))
+}
+
+func (h *CompetitionHandler) GetTransferWindows(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+
+	list, err := h.service.GetTransferWindows(uint(id), uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data jendela transfer berhasil diambil", dto.ToTransferWindowDTOList(list))
+}
+
+func (h *CompetitionHandler) DeleteTransferWindow(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Param("season_id"))
+	windowID, _ := strconv.Atoi(c.Param("window_id"))
+
+	if err := h.service.DeleteTransferWindow(uint(id), uint(seasonID), uint(windowID)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Jendela transfer berhasil dihapus", nil)
+}
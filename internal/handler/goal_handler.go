@@ -6,6 +6,7 @@ import (
 	"football-backend/internal/response"
 	"football-backend/internal/service"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,6 +24,8 @@ func (h *GoalHandler) AddGoal(c *gin.Context) {
 		MatchID        uint   `json:"match_id" binding:"required"`
 		TeamID         uint   `json:"team_id" binding:"required"`
 		ScorerPlayerID uint   `json:"scorer_player_id" binding:"required"`
+		AssistPlayerID *uint  `json:"assist_player_id"`
+		Type           string `json:"type"`
 		Minute         string `json:"minute" binding:"required"`
 	}
 
@@ -35,6 +38,8 @@ func (h *GoalHandler) AddGoal(c *gin.Context) {
 		MatchID:        input.MatchID,
 		TeamID:         input.TeamID,
 		ScorerPlayerID: input.ScorerPlayerID,
+		AssistPlayerID: input.AssistPlayerID,
+		Type:           strings.ToUpper(input.Type),
 		Minute:         input.Minute,
 	}
 
@@ -46,6 +51,63 @@ func (h *GoalHandler) AddGoal(c *gin.Context) {
 	response.Success(c, 201, "Gol berhasil ditambahkan", nil)
 }
 
+func (h *GoalHandler) UpdateGoal(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		TeamID         uint   `json:"team_id" binding:"required"`
+		ScorerPlayerID uint   `json:"scorer_player_id" binding:"required"`
+		AssistPlayerID *uint  `json:"assist_player_id"`
+		Type           string `json:"type"`
+		Minute         string `json:"minute" binding:"required"`
+		Reason         string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+
+	goal := models.Goal{
+		TeamID:         input.TeamID,
+		ScorerPlayerID: input.ScorerPlayerID,
+		AssistPlayerID: input.AssistPlayerID,
+		Type:           strings.ToUpper(input.Type),
+		Minute:         input.Minute,
+	}
+
+	if err := h.service.UpdateGoal(uint(id), &goal, uid.(uint), input.Reason); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Gol berhasil diperbarui", nil)
+}
+
+func (h *GoalHandler) DeleteGoal(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+
+	if err := h.service.DeleteGoal(uint(id), uid.(uint), input.Reason); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Gol berhasil dihapus", nil)
+}
+
 func (h *GoalHandler) GetByMatch(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("match_id"))
 
@@ -64,7 +126,9 @@ func (h *GoalHandler) GetByMatch(c *gin.Context) {
 }
 
 func (h *GoalHandler) TopScorers(c *gin.Context) {
-	list, err := h.service.TopScorers(10)
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+
+	list, err := h.service.TopScorers(uint(seasonID), 10)
 	if err != nil {
 		response.FromError(c, err)
 		return
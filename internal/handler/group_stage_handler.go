@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GroupStageHandler struct {
+	service service.GroupStageService
+}
+
+func NewGroupStageHandler(s service.GroupStageService) *GroupStageHandler {
+	return &GroupStageHandler{s}
+}
+
+func (h *GroupStageHandler) Create(c *gin.Context) {
+	var input struct {
+		SeasonID           uint   `json:"season_id" binding:"required"`
+		Name               string `json:"name" binding:"required"`
+		QualifiersPerGroup int    `json:"qualifiers_per_group"`
+		BestThirdPlaced    int    `json:"best_third_placed"`
+		DrawRule           string `json:"draw_rule"`
+		TwoLegged          bool   `json:"two_legged"`
+		AwayGoalsRule      bool   `json:"away_goals_rule"`
+		ExtraTime          *bool  `json:"extra_time"`
+		Groups             []struct {
+			Name    string `json:"name" binding:"required"`
+			TeamIDs []uint `json:"team_ids" binding:"required"`
+		} `json:"groups" binding:"required"`
+		FixtureStartDate string `json:"fixture_start_date"`
+		IntervalDays     int    `json:"interval_days"`
+		DoubleRound      bool   `json:"double_round"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	opt := service.GroupStageOptions{
+		SeasonID:           input.SeasonID,
+		Name:               input.Name,
+		QualifiersPerGroup: input.QualifiersPerGroup,
+		BestThirdPlaced:    input.BestThirdPlaced,
+		DrawRule:           input.DrawRule,
+		TwoLegged:          input.TwoLegged,
+		AwayGoalsRule:      input.AwayGoalsRule,
+		ExtraTime:          true,
+		IntervalDays:       input.IntervalDays,
+		DoubleRound:        input.DoubleRound,
+	}
+
+	if opt.QualifiersPerGroup == 0 {
+		opt.QualifiersPerGroup = 2
+	}
+	if opt.DrawRule == "" {
+		opt.DrawRule = "JUARA_VS_RUNNER_UP"
+	}
+	if input.ExtraTime != nil {
+		opt.ExtraTime = *input.ExtraTime
+	}
+	if opt.IntervalDays == 0 {
+		opt.IntervalDays = 7
+	}
+
+	if input.FixtureStartDate != "" {
+		start, err := time.Parse(time.RFC3339, input.FixtureStartDate)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		opt.FixtureStart = &start
+	}
+
+	for _, g := range input.Groups {
+		opt.Groups = append(opt.Groups, service.GroupInput{Name: g.Name, TeamIDs: g.TeamIDs})
+	}
+
+	gs, err := h.service.Create(opt)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Fase grup berhasil dibuat", dto.ToGroupStageDTO(gs))
+}
+
+func (h *GroupStageHandler) GetByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	gs, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data fase grup berhasil diambil", dto.ToGroupStageDTO(gs))
+}
+
+func (h *GroupStageHandler) GetBySeason(c *gin.Context) {
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+
+	list, err := h.service.GetBySeason(uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data fase grup berhasil diambil", dto.ToGroupStageDTOList(list))
+}
+
+func (h *GroupStageHandler) Standing(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	tables, err := h.service.Standings(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Klasemen grup berhasil diambil", tables)
+}
+
+func (h *GroupStageHandler) DrawKnockout(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		StartDate         string `json:"start_date" binding:"required"`
+		RoundIntervalDays int    `json:"round_interval_days"`
+		LegIntervalDays   int    `json:"leg_interval_days"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, input.StartDate)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	if input.RoundIntervalDays == 0 {
+		input.RoundIntervalDays = 14
+	}
+	if input.LegIntervalDays == 0 {
+		input.LegIntervalDays = 7
+	}
+
+	b, err := h.service.DrawKnockout(uint(id), service.KnockoutDrawOptions{
+		StartDate:         start,
+		RoundIntervalDays: input.RoundIntervalDays,
+		LegIntervalDays:   input.LegIntervalDays,
+	})
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Undian fase knockout berhasil", dto.ToBracketDTO(b))
+}
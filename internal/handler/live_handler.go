@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"fmt"
+	"football-backend/internal/live"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const liveWriteTimeout = 10 * time.Second
+
+type LiveHandler struct {
+	hub          *live.Hub
+	matchService service.MatchService
+	upgrader     websocket.Upgrader
+}
+
+func NewLiveHandler(hub *live.Hub, m service.MatchService, allowedOrigins []string) *LiveHandler {
+	return &LiveHandler{
+		hub:          hub,
+		matchService: m,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     originChecker(allowedOrigins),
+		},
+	}
+}
+
+func originChecker(allowed []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		if strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+
+		for _, o := range allowed {
+			if o == "*" || strings.EqualFold(strings.TrimRight(o, "/"), origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (h *LiveHandler) MatchSSE(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	h.serveSSE(c, uint(id))
+}
+
+func (h *LiveHandler) AllSSE(c *gin.Context) {
+	h.serveSSE(c, 0)
+}
+
+func (h *LiveHandler) MatchWS(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	h.serveWS(c, uint(id))
+}
+
+func (h *LiveHandler) AllWS(c *gin.Context) {
+	h.serveWS(c, 0)
+}
+
+func (h *LiveHandler) snapshot(c *gin.Context, matchID uint) ([]live.Event, bool) {
+	list, err := h.matchService.LiveSnapshot(matchID)
+	if err != nil {
+		response.FromError(c, err)
+		return nil, false
+	}
+
+	events := make([]live.Event, 0, len(list))
+	for _, m := range list {
+		e, err := h.hub.NewEvent("SNAPSHOT", m.MatchID, m)
+		if err != nil {
+			response.Error(c, 500, "Gagal menyiapkan snapshot")
+			return nil, false
+		}
+		events = append(events, e)
+	}
+	return events, true
+}
+
+func (h *LiveHandler) serveSSE(c *gin.Context, matchID uint) {
+	client := h.hub.Subscribe(matchID)
+	defer h.hub.Unsubscribe(client)
+
+	snapshot, ok := h.snapshot(c, matchID)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+
+	for _, e := range snapshot {
+		c.SSEvent(e.Type, e)
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(h.hub.Heartbeat())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-client.Done():
+			return
+		case e := <-client.Events():
+			c.SSEvent(e.Type, e)
+			c.Writer.Flush()
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
+
+func (h *LiveHandler) serveWS(c *gin.Context, matchID uint) {
+	client := h.hub.Subscribe(matchID)
+	defer h.hub.Unsubscribe(client)
+
+	snapshot, ok := h.snapshot(c, matchID)
+	if !ok {
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	heartbeat := h.hub.Heartbeat()
+	conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * heartbeat))
+	})
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, e := range snapshot {
+		conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+		if err := conn.WriteJSON(e); err != nil {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-client.Done():
+			conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "client terlalu lambat"),
+				time.Now().Add(liveWriteTimeout),
+			)
+			return
+		case e := <-client.Events():
+			conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+			if err := conn.WriteJSON(e); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MatchEventHandler struct {
+	service service.MatchEventService
+}
+
+func NewMatchEventHandler(s service.MatchEventService) *MatchEventHandler {
+	return &MatchEventHandler{s}
+}
+
+func (h *MatchEventHandler) Record(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		Type            string `json:"type" binding:"required"`
+		TeamID          uint   `json:"team_id" binding:"required"`
+		PlayerID        uint   `json:"player_id" binding:"required"`
+		RelatedPlayerID *uint  `json:"related_player_id"`
+		Minute          string `json:"minute" binding:"required"`
+		Note            string `json:"note"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	event := models.MatchEvent{
+		MatchID:         uint(id),
+		TeamID:          input.TeamID,
+		PlayerID:        input.PlayerID,
+		RelatedPlayerID: input.RelatedPlayerID,
+		Type:            strings.ToUpper(input.Type),
+		Minute:          input.Minute,
+		Note:            input.Note,
+	}
+
+	if err := h.service.Record(&event); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Event pertandingan berhasil ditambahkan", map[string]interface{}{
+		"id":      event.ID,
+		"type":    event.Type,
+		"goal_id": event.GoalID,
+	})
+}
+
+func (h *MatchEventHandler) Update(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	eventID, _ := strconv.Atoi(c.Param("event_id"))
+
+	var input struct {
+		Type            string `json:"type"`
+		TeamID          uint   `json:"team_id" binding:"required"`
+		PlayerID        uint   `json:"player_id" binding:"required"`
+		RelatedPlayerID *uint  `json:"related_player_id"`
+		Minute          string `json:"minute" binding:"required"`
+		Note            string `json:"note"`
+		Reason          string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+
+	changes := models.MatchEvent{
+		TeamID:          input.TeamID,
+		PlayerID:        input.PlayerID,
+		RelatedPlayerID: input.RelatedPlayerID,
+		Type:            strings.ToUpper(input.Type),
+		Minute:          input.Minute,
+		Note:            input.Note,
+	}
+
+	event, err := h.service.Update(uint(id), uint(eventID), &changes, uid.(uint), input.Reason)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Event pertandingan berhasil diperbarui", map[string]interface{}{
+		"id":      event.ID,
+		"type":    event.Type,
+		"goal_id": event.GoalID,
+	})
+}
+
+func (h *MatchEventHandler) Delete(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	eventID, _ := strconv.Atoi(c.Param("event_id"))
+
+	var input struct {
+		Reason string `json:"reason" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	uid, _ := c.Get("user_id")
+
+	if err := h.service.Delete(uint(id), uint(eventID), uid.(uint), input.Reason); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Event pertandingan berhasil dihapus", nil)
+}
+
+func (h *MatchEventHandler) Revisions(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.service.Revisions(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Riwayat koreksi berhasil diambil", dto.ToMatchEventRevisionDTOList(list))
+}
+
+func (h *MatchEventHandler) Timeline(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	timeline, err := h.service.Timeline(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Timeline pertandingan berhasil diambil", timeline)
+}
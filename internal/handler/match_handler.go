@@ -7,18 +7,18 @@ import (
 	"football-backend/internal/service"
 	"football-backend/internal/utils"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type MatchHandler struct {
-	service     service.MatchService
-	goalService service.GoalService
+	service service.MatchService
 }
 
-func NewMatchHandler(s service.MatchService, g service.GoalService) *MatchHandler {
-	return &MatchHandler{s, g}
+func NewMatchHandler(s service.MatchService) *MatchHandler {
+	return &MatchHandler{s}
 }
 
 func (h *MatchHandler) Create(c *gin.Context) {
@@ -26,6 +26,8 @@ func (h *MatchHandler) Create(c *gin.Context) {
 		MatchDateTime string `json:"match_date_time" binding:"required"`
 		HomeTeamID    uint   `json:"home_team_id" binding:"required"`
 		AwayTeamID    uint   `json:"away_team_id" binding:"required"`
+		SeasonID      *uint  `json:"season_id"`
+		VenueID       *uint  `json:"venue_id"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -43,6 +45,8 @@ func (h *MatchHandler) Create(c *gin.Context) {
 		MatchDateTime: dt,
 		HomeTeamID:    input.HomeTeamID,
 		AwayTeamID:    input.AwayTeamID,
+		SeasonID:      input.SeasonID,
+		VenueID:       input.VenueID,
 	}
 
 	if err := h.service.Create(&m); err != nil {
@@ -94,6 +98,8 @@ func (h *MatchHandler) Update(c *gin.Context) {
 	var input struct {
 		Status        string `json:"status"`
 		MatchDateTime string `json:"match_date_time"`
+		SeasonID      *uint  `json:"season_id"`
+		VenueID       *uint  `json:"venue_id"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -102,7 +108,8 @@ func (h *MatchHandler) Update(c *gin.Context) {
 	}
 
 	if input.Status != "" {
-		m.Status = input.Status
+		response.Error(c, 400, "Status hanya dapat diubah melalui endpoint transisi status")
+		return
 	}
 
 	if input.MatchDateTime != "" {
@@ -111,6 +118,19 @@ func (h *MatchHandler) Update(c *gin.Context) {
 		}
 	}
 
+	if input.SeasonID != nil {
+		m.SeasonID = input.SeasonID
+		m.Season = nil
+	}
+
+	if input.VenueID != nil {
+		m.VenueID = input.VenueID
+		if *input.VenueID == 0 {
+			m.VenueID = nil
+		}
+		m.Venue = nil
+	}
+
 	if err := h.service.Update(m); err != nil {
 		response.FromError(c, err)
 		return
@@ -127,8 +147,11 @@ func (h *MatchHandler) SubmitResult(c *gin.Context) {
 		Goals []struct {
 			TeamID         uint   `json:"team_id"`
 			ScorerPlayerID uint   `json:"scorer_player_id"`
+			AssistPlayerID *uint  `json:"assist_player_id"`
+			Type           string `json:"type"`
 			Minute         string `json:"minute"`
 		}
+		ExtraTime bool `json:"extra_time"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -136,20 +159,18 @@ func (h *MatchHandler) SubmitResult(c *gin.Context) {
 		return
 	}
 
+	goals := make([]models.Goal, 0, len(input.Goals))
 	for _, g := range input.Goals {
-		newGoal := models.Goal{
-			MatchID:        uint(id),
+		goals = append(goals, models.Goal{
 			TeamID:         g.TeamID,
 			ScorerPlayerID: g.ScorerPlayerID,
+			AssistPlayerID: g.AssistPlayerID,
+			Type:           strings.ToUpper(g.Type),
 			Minute:         g.Minute,
-		}
-		if err := h.goalService.AddGoal(&newGoal); err != nil {
-			response.FromError(c, err)
-			return
-		}
+		})
 	}
 
-	if err := h.service.ProcessResult(uint(id)); err != nil {
+	if err := h.service.SubmitResult(uint(id), goals, input.ExtraTime); err != nil {
 		response.FromError(c, err)
 		return
 	}
@@ -157,6 +178,133 @@ func (h *MatchHandler) SubmitResult(c *gin.Context) {
 	response.Success(c, 200, "Hasil pertandingan berhasil disimpan", nil)
 }
 
+func (h *MatchHandler) changeStatus(c *gin.Context, action string) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	if _, err := h.service.Transition(uint(id), action); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	updated, _ := h.service.GetByID(uint(id))
+	response.Success(c, 200, "Status pertandingan berhasil diperbarui", dto.ToMatchDTO(updated))
+}
+
+func (h *MatchHandler) Start(c *gin.Context) {
+	h.changeStatus(c, service.ActionStart)
+}
+
+func (h *MatchHandler) HalfTime(c *gin.Context) {
+	h.changeStatus(c, service.ActionHalfTime)
+}
+
+func (h *MatchHandler) Resume(c *gin.Context) {
+	h.changeStatus(c, service.ActionResume)
+}
+
+func (h *MatchHandler) Finish(c *gin.Context) {
+	h.changeStatus(c, service.ActionFinish)
+}
+
+func (h *MatchHandler) Postpone(c *gin.Context) {
+	h.changeStatus(c, service.ActionPostpone)
+}
+
+func (h *MatchHandler) Abandon(c *gin.Context) {
+	h.changeStatus(c, service.ActionAbandon)
+}
+
+func (h *MatchHandler) Cancel(c *gin.Context) {
+	h.changeStatus(c, service.ActionCancel)
+}
+
+func (h *MatchHandler) Reschedule(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		MatchDateTime string `json:"match_date_time" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	at, err := time.Parse(time.RFC3339, input.MatchDateTime)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	if _, err := h.service.Reschedule(uint(id), at); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	updated, _ := h.service.GetByID(uint(id))
+	response.Success(c, 200, "Pertandingan berhasil dijadwalkan ulang", dto.ToMatchDTO(updated))
+}
+
+func (h *MatchHandler) GenerateFixtures(c *gin.Context) {
+	var input struct {
+		SeasonID     uint   `json:"season_id" binding:"required"`
+		TeamIDs      []uint `json:"team_ids" binding:"required"`
+		StartDate    string `json:"start_date" binding:"required"`
+		IntervalDays int    `json:"interval_days"`
+		DoubleRound  bool   `json:"double_round"`
+		DryRun       bool   `json:"dry_run"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	start, err := time.Parse(time.RFC3339, input.StartDate)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	if input.IntervalDays == 0 {
+		input.IntervalDays = 7
+	}
+
+	fixtures, err := h.service.GenerateFixtures(service.FixtureOptions{
+		SeasonID:     input.SeasonID,
+		TeamIDs:      input.TeamIDs,
+		StartDate:    start,
+		IntervalDays: input.IntervalDays,
+		DoubleRound:  input.DoubleRound,
+		DryRun:       input.DryRun,
+	})
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	rounds := 0
+	for _, f := range fixtures {
+		if f.Round > rounds {
+			rounds = f.Round
+		}
+	}
+
+	data := gin.H{
+		"dry_run":       input.DryRun,
+		"rounds":        rounds,
+		"total_matches": len(fixtures),
+		"fixtures":      dto.ToFixtureDTOList(fixtures),
+	}
+
+	if input.DryRun {
+		response.Success(c, 200, "Preview jadwal berhasil dibuat", data)
+		return
+	}
+
+	response.Success(c, 201, "Jadwal pertandingan berhasil dibuat", data)
+}
+
 func (h *MatchHandler) Report(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 
@@ -170,7 +318,11 @@ func (h *MatchHandler) Report(c *gin.Context) {
 }
 
 func (h *MatchHandler) Standing(c *gin.Context) {
-	data, err := h.service.LeagueStanding()
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+
+	venue := strings.ToUpper(c.Query("venue"))
+
+	data, err := h.service.LeagueStanding(uint(seasonID), venue)
 	if err != nil {
 		response.FromError(c, err)
 		return
@@ -178,3 +330,15 @@ func (h *MatchHandler) Standing(c *gin.Context) {
 
 	response.Success(c, 200, "Standing berhasil diambil", data)
 }
+
+func (h *MatchHandler) PositionHistory(c *gin.Context) {
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+
+	data, err := h.service.PositionHistory(uint(seasonID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Riwayat posisi berhasil diambil", data)
+}
@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MatchLineupHandler struct {
+	service service.MatchLineupService
+}
+
+func NewMatchLineupHandler(s service.MatchLineupService) *MatchLineupHandler {
+	return &MatchLineupHandler{s}
+}
+
+func (h *MatchLineupHandler) Submit(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		TeamID          uint   `json:"team_id" binding:"required"`
+		Formation       string `json:"formation" binding:"required"`
+		CaptainPlayerID uint   `json:"captain_player_id" binding:"required"`
+		Players         []struct {
+			PlayerID     uint `json:"player_id" binding:"required"`
+			JerseyNumber int  `json:"jersey_number" binding:"required"`
+			Starter      bool `json:"starter"`
+		} `json:"players" binding:"required,dive"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	lineup := models.MatchLineup{
+		MatchID:         uint(id),
+		TeamID:          input.TeamID,
+		Formation:       input.Formation,
+		CaptainPlayerID: input.CaptainPlayerID,
+	}
+
+	for _, p := range input.Players {
+		lineup.Players = append(lineup.Players, models.LineupPlayer{
+			PlayerID:     p.PlayerID,
+			JerseyNumber: p.JerseyNumber,
+			Starter:      p.Starter,
+		})
+	}
+
+	if err := h.service.Submit(&lineup); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Susunan pemain berhasil disimpan", nil)
+}
+
+func (h *MatchLineupHandler) GetByMatch(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.service.GetByMatch(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Susunan pemain berhasil diambil", dto.ToMatchLineupDTOList(list))
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"football-backend/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OfficialHandler struct {
+	service service.OfficialService
+}
+
+func NewOfficialHandler(s service.OfficialService) *OfficialHandler {
+	return &OfficialHandler{s}
+}
+
+func (h *OfficialHandler) Create(c *gin.Context) {
+	var input struct {
+		Name        string `json:"name" binding:"required"`
+		Nationality string `json:"nationality"`
+		BirthDate   string `json:"birth_date"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	official := models.Official{
+		Name:        input.Name,
+		Nationality: input.Nationality,
+	}
+	if input.BirthDate != "" {
+		birthDate, err := time.Parse(time.RFC3339, input.BirthDate)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		official.BirthDate = &birthDate
+	}
+
+	if err := h.service.Create(&official); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Ofisial berhasil dibuat", dto.ToOfficialDTO(&official))
+}
+
+func (h *OfficialHandler) Update(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		Name        *string `json:"name"`
+		Nationality *string `json:"nationality"`
+		BirthDate   *string `json:"birth_date"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	official, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	if input.Name != nil {
+		official.Name = *input.Name
+	}
+	if input.Nationality != nil {
+		official.Nationality = *input.Nationality
+	}
+	if input.BirthDate != nil {
+		official.BirthDate = nil
+		if *input.BirthDate != "" {
+			birthDate, err := time.Parse(time.RFC3339, *input.BirthDate)
+			if err != nil {
+				response.Error(c, 400, "Format tanggal harus RFC3339")
+				return
+			}
+			official.BirthDate = &birthDate
+		}
+	}
+
+	if err := h.service.Update(official); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Ofisial berhasil diperbarui", dto.ToOfficialDTO(official))
+}
+
+func (h *OfficialHandler) Delete(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Ofisial berhasil dihapus", nil)
+}
+
+func (h *OfficialHandler) GetByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	official, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data ofisial berhasil diambil", dto.ToOfficialDTO(official))
+}
+
+func (h *OfficialHandler) GetAll(c *gin.Context) {
+	q := utils.ParseQuery(c)
+
+	result, err := h.service.GetList(q)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	items := result["items"].([]models.Official)
+	result["items"] = dto.ToOfficialDTOList(items)
+
+	response.Success(c, 200, "Data ofisial berhasil diambil", result)
+}
+
+func (h *OfficialHandler) Stats(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+	competitionID, _ := strconv.Atoi(c.Query("competition_id"))
+
+	stats, err := h.service.Stats(uint(id), repository.OfficialStatFilter{
+		SeasonID:      uint(seasonID),
+		CompetitionID: uint(competitionID),
+	})
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Statistik ofisial berhasil diambil", stats)
+}
+
+func (h *OfficialHandler) MatchOfficials(c *gin.Context) {
+	matchID, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.service.MatchOfficials(uint(matchID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Ofisial pertandingan berhasil diambil", dto.ToMatchOfficialsDTO(uint(matchID), list))
+}
+
+func (h *OfficialHandler) AssignMatch(c *gin.Context) {
+	matchID, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		RefereeID        uint `json:"referee_id"`
+		Assistant1ID     uint `json:"assistant_1_id"`
+		Assistant2ID     uint `json:"assistant_2_id"`
+		FourthOfficialID uint `json:"fourth_official_id"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	list, err := h.service.AssignMatch(uint(matchID), map[string]uint{
+		service.OfficialReferee:    input.RefereeID,
+		service.OfficialAssistant1: input.Assistant1ID,
+		service.OfficialAssistant2: input.Assistant2ID,
+		service.OfficialFourth:     input.FourthOfficialID,
+	})
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Ofisial pertandingan berhasil disimpan", dto.ToMatchOfficialsDTO(uint(matchID), list))
+}
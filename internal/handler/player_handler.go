@@ -3,20 +3,25 @@ package handler
 import (
 	"football-backend/internal/dto"
 	"football-backend/internal/models"
+	"football-backend/internal/repository"
 	"football-backend/internal/response"
 	"football-backend/internal/service"
 	"football-backend/internal/utils"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type PlayerHandler struct {
-	playerService service.PlayerService
+	playerService       service.PlayerService
+	loanService         service.PlayerLoanService
+	availabilityService service.PlayerAvailabilityService
 }
 
-func NewPlayerHandler(s service.PlayerService) *PlayerHandler {
-	return &PlayerHandler{s}
+func NewPlayerHandler(s service.PlayerService, l service.PlayerLoanService, a service.PlayerAvailabilityService) *PlayerHandler {
+	return &PlayerHandler{s, l, a}
 }
 
 func (h *PlayerHandler) Create(c *gin.Context) {
@@ -27,6 +32,11 @@ func (h *PlayerHandler) Create(c *gin.Context) {
 		WeightKG     int    `json:"weight"`
 		Position     string `json:"position" binding:"required"`
 		JerseyNumber int    `json:"jersey_number" binding:"required"`
+		Nationality  string `json:"nationality"`
+
+		ContractStart string `json:"contract_start"`
+		ContractEnd   string `json:"contract_end"`
+		SquadRole     string `json:"squad_role"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -41,9 +51,32 @@ func (h *PlayerHandler) Create(c *gin.Context) {
 		WeightKG:     input.WeightKG,
 		Position:     input.Position,
 		JerseyNumber: input.JerseyNumber,
+		Nationality:  input.Nationality,
+	}
+
+	var contract *models.PlayerContract
+	if input.ContractEnd != "" {
+		end, err := time.Parse(time.RFC3339, input.ContractEnd)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		contract = &models.PlayerContract{
+			EndDate:   end,
+			SquadRole: strings.ToUpper(input.SquadRole),
+		}
+
+		if input.ContractStart != "" {
+			start, err := time.Parse(time.RFC3339, input.ContractStart)
+			if err != nil {
+				response.Error(c, 400, "Format tanggal harus RFC3339")
+				return
+			}
+			contract.StartDate = start
+		}
 	}
 
-	if err := h.playerService.Create(&p); err != nil {
+	if err := h.playerService.Create(&p, contract); err != nil {
 		response.FromError(c, err)
 		return
 	}
@@ -112,8 +145,11 @@ func (h *PlayerHandler) Transfer(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
 
 	var input struct {
-		NewTeamID    uint `json:"new_team_id" binding:"required"`
-		JerseyNumber int  `json:"jersey_number" binding:"required"`
+		NewTeamID      uint   `json:"new_team_id" binding:"required"`
+		JerseyNumber   int    `json:"jersey_number" binding:"required"`
+		ContractEnd    string `json:"contract_end" binding:"required"`
+		SquadRole      string `json:"squad_role"`
+		OverrideWindow bool   `json:"override_window"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -121,7 +157,26 @@ func (h *PlayerHandler) Transfer(c *gin.Context) {
 		return
 	}
 
-	if err := h.playerService.TransferPlayer(uint(id), input.NewTeamID, input.JerseyNumber); err != nil {
+	role, _ := c.Get("role")
+	if input.OverrideWindow && role != "ADMIN" {
+		response.Error(c, 403, "Override jendela transfer hanya untuk ADMIN")
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, input.ContractEnd)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	contract := models.PlayerContract{
+		TeamID:       input.NewTeamID,
+		JerseyNumber: input.JerseyNumber,
+		EndDate:      end,
+		SquadRole:    strings.ToUpper(input.SquadRole),
+	}
+
+	if err := h.playerService.TransferPlayer(uint(id), &contract, input.OverrideWindow); err != nil {
 		response.FromError(c, err)
 		return
 	}
@@ -129,6 +184,355 @@ func (h *PlayerHandler) Transfer(c *gin.Context) {
 	response.Success(c, 200, "Transfer pemain berhasil", nil)
 }
 
+func (h *PlayerHandler) Transfers(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.playerService.Transfers(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Riwayat transfer pemain berhasil diambil", dto.ToPlayerTransferDTOList(list))
+}
+
+func (h *PlayerHandler) Loan(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		LoanTeamID     uint   `json:"loan_team_id" binding:"required"`
+		JerseyNumber   int    `json:"jersey_number" binding:"required"`
+		StartDate      string `json:"start_date"`
+		EndDate        string `json:"end_date" binding:"required"`
+		OptionToBuy    bool   `json:"option_to_buy"`
+		OverrideWindow bool   `json:"override_window"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	role, _ := c.Get("role")
+	if input.OverrideWindow && role != "ADMIN" {
+		response.Error(c, 403, "Override jendela transfer hanya untuk ADMIN")
+		return
+	}
+
+	var startDate time.Time
+	if input.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, input.StartDate)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		startDate = t
+	}
+	endDate, err := time.Parse(time.RFC3339, input.EndDate)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	loan := models.PlayerLoan{
+		PlayerID:         uint(id),
+		LoanTeamID:       input.LoanTeamID,
+		LoanJerseyNumber: input.JerseyNumber,
+		StartDate:        startDate,
+		EndDate:          endDate,
+		OptionToBuy:      input.OptionToBuy,
+	}
+
+	if err := h.loanService.Create(&loan, input.OverrideWindow); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Peminjaman pemain berhasil dibuat", map[string]interface{}{
+		"id":     loan.ID,
+		"status": loan.Status,
+	})
+}
+
+func (h *PlayerHandler) Loans(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.loanService.GetByPlayer(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data peminjaman pemain berhasil diambil", dto.ToPlayerLoanDTOList(list))
+}
+
+func (h *PlayerHandler) ExerciseLoanOption(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	loanID, _ := strconv.Atoi(c.Param("loan_id"))
+
+	var input struct {
+		ContractEnd string `json:"contract_end" binding:"required"`
+		SquadRole   string `json:"squad_role"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, input.ContractEnd)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	contract := models.PlayerContract{
+		EndDate:   end,
+		SquadRole: strings.ToUpper(input.SquadRole),
+	}
+
+	loan, err := h.loanService.ExerciseOption(uint(id), uint(loanID), &contract)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Opsi pembelian berhasil digunakan", dto.ToPlayerLoanDTO(loan))
+}
+
+func (h *PlayerHandler) Contracts(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.playerService.Contracts(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data kontrak pemain berhasil diambil", dto.ToPlayerContractDTOList(list))
+}
+
+func (h *PlayerHandler) UpdateContract(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		StartDate string `json:"start_date"`
+		EndDate   string `json:"end_date" binding:"required"`
+		SquadRole string `json:"squad_role"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	end, err := time.Parse(time.RFC3339, input.EndDate)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	changes := models.PlayerContract{
+		EndDate:   end,
+		SquadRole: strings.ToUpper(input.SquadRole),
+	}
+
+	if input.StartDate != "" {
+		start, err := time.Parse(time.RFC3339, input.StartDate)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		changes.StartDate = start
+	}
+
+	contract, err := h.playerService.UpdateContract(uint(id), &changes)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Kontrak pemain berhasil disimpan", dto.ToPlayerContractDTO(contract))
+}
+
+func (h *PlayerHandler) ExpiringContracts(c *gin.Context) {
+	days, _ := strconv.Atoi(c.Query("days"))
+	teamID, _ := strconv.Atoi(c.Query("team_id"))
+
+	list, err := h.playerService.ExpiringContracts(days, uint(teamID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data kontrak yang akan berakhir berhasil diambil", dto.ToPlayerContractDTOList(list))
+}
+
+func (h *PlayerHandler) AddInjury(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		Type           string `json:"type" binding:"required"`
+		Note           string `json:"note"`
+		StartDate      string `json:"start_date"`
+		ExpectedReturn string `json:"expected_return" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	expectedReturn, err := time.Parse(time.RFC3339, input.ExpectedReturn)
+	if err != nil {
+		response.Error(c, 400, "Format tanggal harus RFC3339")
+		return
+	}
+
+	injury := models.PlayerInjury{
+		PlayerID:       uint(id),
+		Type:           input.Type,
+		Note:           input.Note,
+		ExpectedReturn: expectedReturn,
+	}
+
+	if input.StartDate != "" {
+		start, err := time.Parse(time.RFC3339, input.StartDate)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		injury.StartDate = start
+	}
+
+	if err := h.availabilityService.AddInjury(&injury); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Data cedera berhasil dicatat", map[string]interface{}{
+		"id": injury.ID,
+	})
+}
+
+func (h *PlayerHandler) UpdateInjury(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	injuryID, _ := strconv.Atoi(c.Param("injury_id"))
+
+	var input struct {
+		ExpectedReturn string `json:"expected_return"`
+		ReturnedAt     string `json:"returned_at"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	var expectedReturn, returnedAt *time.Time
+	if input.ExpectedReturn != "" {
+		t, err := time.Parse(time.RFC3339, input.ExpectedReturn)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		expectedReturn = &t
+	}
+	if input.ReturnedAt != "" {
+		t, err := time.Parse(time.RFC3339, input.ReturnedAt)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		returnedAt = &t
+	}
+
+	injury, err := h.availabilityService.UpdateInjury(uint(id), uint(injuryID), expectedReturn, returnedAt)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data cedera berhasil diperbarui", dto.ToPlayerInjuryDTO(injury))
+}
+
+func (h *PlayerHandler) Injuries(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.availabilityService.Injuries(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data cedera pemain berhasil diambil", dto.ToPlayerInjuryDTOList(list))
+}
+
+func (h *PlayerHandler) AddSuspension(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		CompetitionID uint   `json:"competition_id" binding:"required"`
+		Matches       int    `json:"matches" binding:"required"`
+		StartsAt      string `json:"starts_at"`
+		Note          string `json:"note" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	suspension := models.PlayerSuspension{
+		PlayerID:      uint(id),
+		CompetitionID: input.CompetitionID,
+		Matches:       input.Matches,
+		Note:          input.Note,
+	}
+
+	if input.StartsAt != "" {
+		t, err := time.Parse(time.RFC3339, input.StartsAt)
+		if err != nil {
+			response.Error(c, 400, "Format tanggal harus RFC3339")
+			return
+		}
+		suspension.StartsAt = t
+	}
+
+	if err := h.availabilityService.AddSuspension(&suspension); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Skorsing pemain berhasil dicatat", map[string]interface{}{
+		"id": suspension.ID,
+	})
+}
+
+func (h *PlayerHandler) Suspensions(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	list, err := h.availabilityService.Suspensions(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data skorsing pemain berhasil diambil", list)
+}
+
+func (h *PlayerHandler) DeleteSuspension(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	suspensionID, _ := strconv.Atoi(c.Param("suspension_id"))
+
+	if err := h.availabilityService.DeleteSuspension(uint(id), uint(suspensionID)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Skorsing pemain berhasil dihapus", nil)
+}
+
 func (h *PlayerHandler) GetAll(c *gin.Context) {
 	q := utils.ParseQuery(c)
 
@@ -145,3 +549,40 @@ func (h *PlayerHandler) GetAll(c *gin.Context) {
 
 	response.Success(c, 200, "Data pemain berhasil diambil", result)
 }
+
+func playerStatFilter(c *gin.Context) repository.PlayerStatFilter {
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+	competitionID, _ := strconv.Atoi(c.Query("competition_id"))
+	minMinutes, _ := strconv.Atoi(c.Query("min_minutes"))
+
+	return repository.PlayerStatFilter{
+		SeasonID:      uint(seasonID),
+		CompetitionID: uint(competitionID),
+		MinMinutes:    minMinutes,
+	}
+}
+
+func (h *PlayerHandler) Stats(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	stats, err := h.playerService.Stats(uint(id), playerStatFilter(c))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Statistik pemain berhasil diambil", stats)
+}
+
+func (h *PlayerHandler) Leaderboard(c *gin.Context) {
+	metric := strings.ToLower(c.Query("metric"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	list, err := h.playerService.Leaderboard(metric, playerStatFilter(c), limit)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Leaderboard pemain berhasil diambil", list)
+}
@@ -12,11 +12,12 @@ import (
 )
 
 type TeamHandler struct {
-	service service.TeamService
+	service             service.TeamService
+	availabilityService service.PlayerAvailabilityService
 }
 
-func NewTeamHandler(s service.TeamService) *TeamHandler {
-	return &TeamHandler{s}
+func NewTeamHandler(s service.TeamService, a service.PlayerAvailabilityService) *TeamHandler {
+	return &TeamHandler{s, a}
 }
 
 func (h *TeamHandler) Create(c *gin.Context) {
@@ -26,6 +27,7 @@ func (h *TeamHandler) Create(c *gin.Context) {
 		YearFounded int    `json:"year_founded"`
 		Address     string `json:"address"`
 		City        string `json:"city"`
+		HomeVenueID *uint  `json:"home_venue_id"`
 	}
 
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -39,6 +41,7 @@ func (h *TeamHandler) Create(c *gin.Context) {
 		YearFounded: input.YearFounded,
 		Address:     input.Address,
 		City:        input.City,
+		HomeVenueID: input.HomeVenueID,
 	}
 
 	if err := h.service.Create(&team); err != nil {
@@ -58,17 +61,50 @@ func (h *TeamHandler) Update(c *gin.Context) {
 		return
 	}
 
-	if err := c.ShouldBindJSON(t); err != nil {
+	var input struct {
+		Name        *string `json:"name"`
+		LogoURL     *string `json:"logo_url"`
+		YearFounded *int    `json:"year_founded"`
+		Address     *string `json:"address"`
+		City        *string `json:"city"`
+		HomeVenueID *uint   `json:"home_venue_id"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
 		response.Error(c, 400, "Input tidak valid")
 		return
 	}
 
+	if input.Name != nil {
+		t.Name = *input.Name
+	}
+	if input.LogoURL != nil {
+		t.LogoURL = *input.LogoURL
+	}
+	if input.YearFounded != nil {
+		t.YearFounded = *input.YearFounded
+	}
+	if input.Address != nil {
+		t.Address = *input.Address
+	}
+	if input.City != nil {
+		t.City = *input.City
+	}
+	if input.HomeVenueID != nil {
+		t.HomeVenueID = input.HomeVenueID
+		if *input.HomeVenueID == 0 {
+			t.HomeVenueID = nil
+		}
+		t.HomeVenue = nil
+	}
+
 	if err := h.service.Update(t); err != nil {
 		response.FromError(c, err)
 		return
 	}
 
-	response.Success(c, 200, "Team berhasil diperbarui", dto.ToTeamDTO(t))
+	updated, _ := h.service.GetByID(t.ID)
+	response.Success(c, 200, "Team berhasil diperbarui", dto.ToTeamDTO(updated))
 }
 
 func (h *TeamHandler) Delete(c *gin.Context) {
@@ -109,3 +145,55 @@ func (h *TeamHandler) GetAll(c *gin.Context) {
 
 	response.Success(c, 200, "Data tim berhasil diambil", result)
 }
+
+func (h *TeamHandler) Stats(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	seasonID, _ := strconv.Atoi(c.Query("season_id"))
+	competitionID, _ := strconv.Atoi(c.Query("competition_id"))
+
+	stats, err := h.service.Stats(uint(id), uint(seasonID), uint(competitionID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Statistik team berhasil diambil", stats)
+}
+
+func (h *TeamHandler) HeadToHead(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	opponentID, _ := strconv.Atoi(c.Param("opponent_id"))
+
+	h2h, err := h.service.HeadToHead(uint(id), uint(opponentID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Head-to-head berhasil diambil", h2h)
+}
+
+func (h *TeamHandler) Transfers(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	transfers, err := h.service.Transfers(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Riwayat transfer team berhasil diambil", transfers)
+}
+
+func (h *TeamHandler) Unavailable(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	matchID, _ := strconv.Atoi(c.Query("match_id"))
+
+	data, err := h.availabilityService.Unavailable(uint(id), uint(matchID))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Daftar pemain absen berhasil diambil", data)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+	"football-backend/internal/response"
+	"football-backend/internal/service"
+	"football-backend/internal/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VenueHandler struct {
+	service service.VenueService
+}
+
+func NewVenueHandler(s service.VenueService) *VenueHandler {
+	return &VenueHandler{s}
+}
+
+func (h *VenueHandler) Create(c *gin.Context) {
+	var input struct {
+		Name      string   `json:"name" binding:"required"`
+		Address   string   `json:"address"`
+		City      string   `json:"city"`
+		Latitude  *float64 `json:"latitude"`
+		Longitude *float64 `json:"longitude"`
+		Capacity  int      `json:"capacity"`
+		Surface   string   `json:"surface"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	venue := models.Venue{
+		Name:      input.Name,
+		Address:   input.Address,
+		City:      input.City,
+		Latitude:  input.Latitude,
+		Longitude: input.Longitude,
+		Capacity:  input.Capacity,
+		Surface:   input.Surface,
+	}
+
+	if err := h.service.Create(&venue); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 201, "Venue berhasil dibuat", dto.ToVenueDTO(&venue))
+}
+
+func (h *VenueHandler) Update(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	var input struct {
+		Name      *string  `json:"name"`
+		Address   *string  `json:"address"`
+		City      *string  `json:"city"`
+		Latitude  *float64 `json:"latitude"`
+		Longitude *float64 `json:"longitude"`
+		Capacity  *int     `json:"capacity"`
+		Surface   *string  `json:"surface"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Error(c, 400, "Input tidak valid")
+		return
+	}
+
+	venue, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	if input.Name != nil {
+		venue.Name = *input.Name
+	}
+	if input.Address != nil {
+		venue.Address = *input.Address
+	}
+	if input.City != nil {
+		venue.City = *input.City
+	}
+	if input.Latitude != nil {
+		venue.Latitude = input.Latitude
+	}
+	if input.Longitude != nil {
+		venue.Longitude = input.Longitude
+	}
+	if input.Capacity != nil {
+		venue.Capacity = *input.Capacity
+	}
+	if input.Surface != nil {
+		venue.Surface = *input.Surface
+	}
+
+	if err := h.service.Update(venue); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Venue berhasil diperbarui", dto.ToVenueDTO(venue))
+}
+
+func (h *VenueHandler) Delete(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Venue berhasil dihapus", nil)
+}
+
+func (h *VenueHandler) GetByID(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	venue, err := h.service.GetByID(uint(id))
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	response.Success(c, 200, "Data venue berhasil diambil", dto.ToVenueDTO(venue))
+}
+
+func (h *VenueHandler) GetAll(c *gin.Context) {
+	q := utils.ParseQuery(c)
+
+	result, err := h.service.GetList(q)
+	if err != nil {
+		response.FromError(c, err)
+		return
+	}
+
+	items := result["items"].([]models.Venue)
+	result["items"] = dto.ToVenueDTOList(items)
+
+	response.Success(c, 200, "Data venue berhasil diambil", result)
+}
@@ -0,0 +1,162 @@
+package live
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBufferSize = 64
+	DefaultHeartbeat  = 25 * time.Second
+)
+
+type Event struct {
+	ID      uint64          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	MatchID uint            `json:"match_id"`
+	Data    json.RawMessage `json:"data"`
+	At      time.Time       `json:"at"`
+}
+
+type Publisher interface {
+	Publish(eventType string, matchID uint, data interface{})
+}
+
+type Backend interface {
+	Publish(e Event) error
+	Subscribe(deliver func(Event)) (func(), error)
+}
+
+type Client struct {
+	matchID uint
+	events  chan Event
+	done    chan struct{}
+	once    sync.Once
+}
+
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Client) close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+type Hub struct {
+	backend    Backend
+	bufferSize int
+	heartbeat  time.Duration
+
+	mu      sync.RWMutex
+	clients map[*Client]struct{}
+	stop    func()
+}
+
+func NewHub(b Backend, bufferSize int, heartbeat time.Duration) (*Hub, error) {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	if heartbeat <= 0 {
+		heartbeat = DefaultHeartbeat
+	}
+
+	h := &Hub{
+		backend:    b,
+		bufferSize: bufferSize,
+		heartbeat:  heartbeat,
+		clients:    map[*Client]struct{}{},
+	}
+
+	stop, err := b.Subscribe(h.deliver)
+	if err != nil {
+		return nil, err
+	}
+	h.stop = stop
+	return h, nil
+}
+
+func (h *Hub) NewEvent(eventType string, matchID uint, data interface{}) (Event, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, err
+	}
+	return Event{
+		Type:    eventType,
+		MatchID: matchID,
+		Data:    raw,
+		At:      time.Now().UTC(),
+	}, nil
+}
+
+func (h *Hub) Publish(eventType string, matchID uint, data interface{}) {
+	e, err := h.NewEvent(eventType, matchID, data)
+	if err != nil {
+		log.Printf("live: failed to encode event %s: %v", eventType, err)
+		return
+	}
+	if err := h.backend.Publish(e); err != nil {
+		log.Printf("live: failed to publish event %s: %v", eventType, err)
+	}
+}
+
+func (h *Hub) Subscribe(matchID uint) *Client {
+	c := &Client{
+		matchID: matchID,
+		events:  make(chan Event, h.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+
+	return c
+}
+
+func (h *Hub) Unsubscribe(c *Client) {
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+
+	c.close()
+}
+
+func (h *Hub) Heartbeat() time.Duration {
+	return h.heartbeat
+}
+
+func (h *Hub) Close() {
+	if h.stop != nil {
+		h.stop()
+	}
+
+	h.mu.Lock()
+	for c := range h.clients {
+		c.close()
+		delete(h.clients, c)
+	}
+	h.mu.Unlock()
+}
+
+func (h *Hub) deliver(e Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for c := range h.clients {
+		if c.matchID != 0 && c.matchID != e.MatchID {
+			continue
+		}
+
+		select {
+		case c.events <- e:
+		default:
+			c.close()
+		}
+	}
+}
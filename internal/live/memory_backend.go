@@ -0,0 +1,43 @@
+package live
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type memoryBackend struct {
+	mu          sync.RWMutex
+	next        int
+	subscribers map[int]func(Event)
+	seq         atomic.Uint64
+}
+
+func NewMemoryBackend() Backend {
+	return &memoryBackend{subscribers: map[int]func(Event){}}
+}
+
+func (b *memoryBackend) Publish(e Event) error {
+	e.ID = b.seq.Add(1)
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, deliver := range b.subscribers {
+		deliver(e)
+	}
+	return nil
+}
+
+func (b *memoryBackend) Subscribe(deliver func(Event)) (func(), error) {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subscribers[id] = deliver
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		delete(b.subscribers, id)
+		b.mu.Unlock()
+	}, nil
+}
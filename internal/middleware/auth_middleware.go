@@ -3,18 +3,17 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"time"
 
-	"football-backend/internal/config"
+	"football-backend/internal/jwtkeys"
 	"football-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func JWTAuth(userRepo repository.UserRepository) gin.HandlerFunc {
+func JWTAuth(userRepo repository.UserRepository, sessionRepo repository.UserSessionRepository, keys *jwtkeys.KeySet) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		jwtKey := []byte(config.Load().JWTSecret)
-
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization header missing"})
@@ -29,59 +28,94 @@ func JWTAuth(userRepo repository.UserRepository) gin.HandlerFunc {
 			return
 		}
 
-		tokenString := parts[1]
-		claims := jwt.MapClaims{}
-
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-			return jwtKey, nil
-		})
+		authenticate(c, userRepo, sessionRepo, keys, parts[1], "")
+	}
+}
 
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
-			c.Abort()
+func LiveTicketAuth(userRepo repository.UserRepository, sessionRepo repository.UserSessionRepository, keys *jwtkeys.KeySet) gin.HandlerFunc {
+	bearer := JWTAuth(userRepo, sessionRepo, keys)
+	return func(c *gin.Context) {
+		ticket := c.Query("ticket")
+		if ticket == "" {
+			bearer(c)
 			return
 		}
+		authenticate(c, userRepo, sessionRepo, keys, ticket, "live")
+	}
+}
 
-		uid, ok := claims["user_id"].(float64)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token payload (user_id)"})
-			c.Abort()
-			return
-		}
-		userID := uint(uid)
+func authenticate(c *gin.Context, userRepo repository.UserRepository, sessionRepo repository.UserSessionRepository, keys *jwtkeys.KeySet, tokenString, tokenType string) {
+	claims := jwt.MapClaims{}
 
-		ver, ok := claims["ver"].(float64)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "token version missing"})
-			c.Abort()
-			return
-		}
+	token, err := keys.Parse(tokenString, claims)
+	if err != nil || !token.Valid {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		c.Abort()
+		return
+	}
 
-		user, err := userRepo.GetByID(userID)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
-			c.Abort()
-			return
-		}
+	if typ, _ := claims["type"].(string); typ != tokenType {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token type"})
+		c.Abort()
+		return
+	}
 
-		if int(ver) != user.TokenVersion {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
-			c.Abort()
-			return
-		}
+	uid, ok := claims["user_id"].(float64)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token payload (user_id)"})
+		c.Abort()
+		return
+	}
+	userID := uint(uid)
 
-		role, ok := claims["role"].(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token payload (role)"})
-			c.Abort()
-			return
-		}
+	ver, ok := claims["ver"].(float64)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "token version missing"})
+		c.Abort()
+		return
+	}
+
+	user, err := userRepo.GetByID(userID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "user not found"})
+		c.Abort()
+		return
+	}
 
-		c.Set("user_id", userID)
-		c.Set("role", role)
+	if int(ver) != user.TokenVersion {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "token has been revoked"})
+		c.Abort()
+		return
+	}
 
-		c.Next()
+	sid, ok := claims["sid"].(float64)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token payload (sid)"})
+		c.Abort()
+		return
+	}
+	sessionID := uint(sid)
+
+	session, err := sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != userID || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "session has been revoked"})
+		c.Abort()
+		return
 	}
+
+	role, ok := claims["role"].(string)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token payload (role)"})
+		c.Abort()
+		return
+	}
+
+	c.Set("user_id", userID)
+	c.Set("role", role)
+	c.Set("session_id", sessionID)
+	c.Set("totp_enabled", user.TOTPEnabled)
+
+	c.Next()
 }
 
 func AdminOnly() gin.HandlerFunc {
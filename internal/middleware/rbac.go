@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -31,3 +32,21 @@ func RequireRoles(allowedRoles ...string) gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+func RequireTwoFactor(requiredRoles []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get("role")
+		enabled, _ := c.Get("totp_enabled")
+
+		for _, required := range requiredRoles {
+			if strings.EqualFold(fmt.Sprint(role), required) && enabled != true {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error": "2FA wajib untuk role ini, aktifkan lewat /auth/2fa/enroll",
+				})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
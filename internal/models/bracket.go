@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Bracket struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	SeasonID uint   `gorm:"index;not null"`
+	Season   Season `gorm:"foreignKey:SeasonID"`
+
+	Name          string `gorm:"size:255;not null"`
+	Size          int
+	Seeded        bool
+	TwoLegged     bool
+	AwayGoalsRule bool
+	ExtraTime     bool
+
+	StartDate         time.Time
+	RoundIntervalDays int
+	LegIntervalDays   int
+
+	Ties []KnockoutTie `gorm:"foreignKey:BracketID"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Competition struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Name    string `gorm:"size:255;unique;not null"`
+	Type    string `gorm:"type:ENUM('LIGA','PIALA','TURNAMEN');default:'LIGA'"`
+	Country string `gorm:"size:255"`
+	LogoURL string `gorm:"size:1024"`
+
+	Seasons []Season `gorm:"foreignKey:CompetitionID"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type DisciplineRule struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	CompetitionID uint `gorm:"uniqueIndex;not null"`
+
+	YellowCardThreshold int
+	YellowCardBan       int
+	SecondYellowBan     int
+	RedCardBan          int
+}
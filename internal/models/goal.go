@@ -21,5 +21,9 @@ type Goal struct {
 	ScorerPlayerID uint
 	Scorer         Player `gorm:"foreignKey:ScorerPlayerID"`
 
+	AssistPlayerID *uint
+	Assist         *Player `gorm:"foreignKey:AssistPlayerID"`
+
+	Type   string `gorm:"type:ENUM('BIASA','PENALTI','BUNUH_DIRI');default:'BIASA'"`
 	Minute string
 }
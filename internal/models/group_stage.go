@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GroupStage struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	SeasonID uint   `gorm:"index;not null"`
+	Season   Season `gorm:"foreignKey:SeasonID"`
+
+	Name               string `gorm:"size:255;not null"`
+	QualifiersPerGroup int    `gorm:"default:2"`
+	BestThirdPlaced    int    `gorm:"default:0"`
+	DrawRule           string `gorm:"type:ENUM('JUARA_VS_RUNNER_UP','UNGGULAN','ACAK');default:'JUARA_VS_RUNNER_UP'"`
+
+	TwoLegged     bool
+	AwayGoalsRule bool
+	ExtraTime     bool
+
+	BracketID *uint
+	Bracket   *Bracket `gorm:"foreignKey:BracketID"`
+
+	Groups []TournamentGroup `gorm:"foreignKey:GroupStageID"`
+}
+
+type TournamentGroup struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	GroupStageID uint   `gorm:"index;not null"`
+	Name         string `gorm:"size:50;not null"`
+
+	Teams   []Team  `gorm:"many2many:tournament_group_teams"`
+	Matches []Match `gorm:"foreignKey:GroupID"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type KnockoutTie struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	BracketID uint    `gorm:"index;not null"`
+	Bracket   Bracket `gorm:"foreignKey:BracketID"`
+
+	Round    int
+	Position int
+
+	HomeTeamID *uint
+	AwayTeamID *uint
+	HomeTeam   *Team `gorm:"foreignKey:HomeTeamID"`
+	AwayTeam   *Team `gorm:"foreignKey:AwayTeamID"`
+
+	WinnerTeamID *uint
+	DecidedBy    string `gorm:"size:50"`
+
+	NextTieID *uint
+	NextSlot  string `gorm:"type:ENUM('HOME','AWAY')"`
+
+	Matches []Match `gorm:"foreignKey:TieID"`
+}
@@ -14,13 +14,29 @@ type Match struct {
 
 	MatchDateTime time.Time
 
+	SeasonID *uint   `gorm:"index"`
+	Season   *Season `gorm:"foreignKey:SeasonID"`
+	Round    int
+
+	GroupID *uint `gorm:"index"`
+
+	TieID     *uint `gorm:"index"`
+	Leg       int
+	ExtraTime bool
+
 	HomeTeamID uint
 	AwayTeamID uint
 
 	HomeTeam Team `gorm:"foreignKey:HomeTeamID"`
 	AwayTeam Team `gorm:"foreignKey:AwayTeamID"`
 
-	Status string `gorm:"type:ENUM('DIJADWALKAN','SEDANG BERLANGSUNG','SELESAI','DIBATALKAN');default:'DIJADWALKAN'"`
+	VenueID *uint  `gorm:"index"`
+	Venue   *Venue `gorm:"foreignKey:VenueID"`
+
+	Status string  `gorm:"type:ENUM('DIJADWALKAN','SEDANG BERLANGSUNG','ISTIRAHAT','SELESAI','DITUNDA','DIHENTIKAN','DIBATALKAN');default:'DIJADWALKAN'"`
+	Result *string `gorm:"type:ENUM('HOME_WIN','AWAY_WIN','DRAW')"`
 
-	Goals []Goal `gorm:"foreignKey:MatchID"`
+	Goals        []Goal        `gorm:"foreignKey:MatchID"`
+	PenaltyKicks []PenaltyKick `gorm:"foreignKey:MatchID"`
+	Events       []MatchEvent  `gorm:"foreignKey:MatchID"`
 }
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MatchEvent struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	MatchID uint  `gorm:"index;not null"`
+	Match   Match `gorm:"foreignKey:MatchID"`
+
+	TeamID uint
+	Team   Team `gorm:"foreignKey:TeamID"`
+
+	PlayerID uint
+	Player   Player `gorm:"foreignKey:PlayerID"`
+
+	RelatedPlayerID *uint
+	RelatedPlayer   *Player `gorm:"foreignKey:RelatedPlayerID"`
+
+	GoalID *uint `gorm:"index"`
+
+	Type   string `gorm:"type:ENUM('GOL','GOL_PENALTI','GOL_BUNUH_DIRI','PENALTI_GAGAL','KARTU_KUNING','KARTU_KUNING_KEDUA','KARTU_MERAH','PERGANTIAN');not null"`
+	Minute string
+	Note   string `gorm:"size:255"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type MatchEventRevision struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	MatchID uint  `gorm:"index;not null"`
+	EventID *uint `gorm:"index"`
+	GoalID  *uint `gorm:"index"`
+
+	Action string `gorm:"type:ENUM('UBAH','HAPUS');not null"`
+	Before string `gorm:"type:text"`
+	After  string `gorm:"type:text"`
+	Reason string `gorm:"size:255;not null"`
+
+	ChangedByID uint
+	ChangedBy   User `gorm:"foreignKey:ChangedByID"`
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type MatchLineup struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	MatchID uint  `gorm:"uniqueIndex:idx_lineup_match_team;not null"`
+	Match   Match `gorm:"foreignKey:MatchID"`
+
+	TeamID uint `gorm:"uniqueIndex:idx_lineup_match_team;not null"`
+	Team   Team `gorm:"foreignKey:TeamID"`
+
+	Formation string `gorm:"size:20"`
+
+	CaptainPlayerID uint
+	Captain         Player `gorm:"foreignKey:CaptainPlayerID"`
+
+	Players []LineupPlayer `gorm:"foreignKey:LineupID"`
+}
+
+type LineupPlayer struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	LineupID uint `gorm:"index;not null"`
+
+	PlayerID uint
+	Player   Player `gorm:"foreignKey:PlayerID"`
+
+	JerseyNumber int
+	Starter      bool
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type MatchOfficial struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	MatchID uint  `gorm:"uniqueIndex:idx_match_official_role;not null"`
+	Match   Match `gorm:"foreignKey:MatchID"`
+
+	OfficialID uint     `gorm:"index;not null"`
+	Official   Official `gorm:"foreignKey:OfficialID"`
+
+	Role string `gorm:"type:ENUM('WASIT','ASISTEN_WASIT_1','ASISTEN_WASIT_2','OFISIAL_KEEMPAT');uniqueIndex:idx_match_official_role;not null"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Official struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Name        string `gorm:"size:255;not null"`
+	Nationality string `gorm:"size:255"`
+	BirthDate   *time.Time
+
+	Assignments []MatchOfficial `gorm:"foreignKey:OfficialID"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type PasswordResetToken struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	UserID uint `gorm:"index;not null"`
+	User   User `gorm:"foreignKey:UserID"`
+
+	TokenHash string `gorm:"size:64;unique;not null"`
+	RequestIP string `gorm:"size:64"`
+
+	ExpiresAt time.Time `gorm:"index"`
+	UsedAt    *time.Time
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PenaltyKick struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	MatchID uint  `gorm:"index;not null"`
+	Match   Match `gorm:"foreignKey:MatchID"`
+
+	TeamID uint
+	Team   Team `gorm:"foreignKey:TeamID"`
+
+	PlayerID uint
+	Player   Player `gorm:"foreignKey:PlayerID"`
+
+	KickOrder int
+	Scored    bool
+}
@@ -20,4 +20,5 @@ type Player struct {
 	WeightKG     int    `json:"weight"`
 	Position     string `gorm:"type:ENUM('PENYERANG','GELANDANG','BERTAHAN','PENJAGA_GAWANG');not null"`
 	JerseyNumber int    `gorm:"not null"`
+	Nationality  string `gorm:"size:255"`
 }
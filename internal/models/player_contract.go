@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlayerContract struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PlayerID uint   `gorm:"index;not null"`
+	Player   Player `gorm:"foreignKey:PlayerID"`
+
+	TeamID uint `gorm:"index;not null"`
+	Team   Team `gorm:"foreignKey:TeamID"`
+
+	StartDate    time.Time
+	EndDate      time.Time `gorm:"index"`
+	SquadRole    string    `gorm:"type:ENUM('INTI','ROTASI','PEMAIN_MUDA','CADANGAN');default:'ROTASI'"`
+	JerseyNumber int
+	TerminatedAt *time.Time
+}
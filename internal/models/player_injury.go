@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlayerInjury struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PlayerID uint   `gorm:"index;not null"`
+	Player   Player `gorm:"foreignKey:PlayerID"`
+
+	Type           string `gorm:"size:255;not null"`
+	Note           string `gorm:"size:1024"`
+	StartDate      time.Time
+	ExpectedReturn time.Time
+	ReturnedAt     *time.Time
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlayerLoan struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PlayerID uint   `gorm:"index;not null"`
+	Player   Player `gorm:"foreignKey:PlayerID"`
+
+	ParentTeamID uint
+	ParentTeam   Team `gorm:"foreignKey:ParentTeamID"`
+
+	LoanTeamID uint
+	LoanTeam   Team `gorm:"foreignKey:LoanTeamID"`
+
+	StartDate time.Time
+	EndDate   time.Time `gorm:"index"`
+
+	OptionToBuy        bool `gorm:"default:false"`
+	ParentJerseyNumber int
+	LoanJerseyNumber   int
+	TransferWindowID   *uint
+	WindowOverride     bool `gorm:"default:false"`
+
+	Status        string `gorm:"type:ENUM('DIJADWALKAN','AKTIF','SELESAI','DIPERMANENKAN','DIBATALKAN','BERMASALAH');default:'DIJADWALKAN';index"`
+	FailureReason string `gorm:"size:255"`
+}
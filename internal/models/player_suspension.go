@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlayerSuspension struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	PlayerID uint   `gorm:"index;not null"`
+	Player   Player `gorm:"foreignKey:PlayerID"`
+
+	CompetitionID uint        `gorm:"index;not null"`
+	Competition   Competition `gorm:"foreignKey:CompetitionID"`
+
+	SourceMatchID *uint `gorm:"index"`
+
+	Reason   string `gorm:"type:ENUM('AKUMULASI_KARTU_KUNING','KARTU_KUNING_KEDUA','KARTU_MERAH','MANUAL');not null"`
+	Matches  int    `gorm:"not null"`
+	StartsAt time.Time
+	Note     string `gorm:"size:1024"`
+}
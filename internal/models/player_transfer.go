@@ -11,8 +11,18 @@ type PlayerTransfer struct {
 	CreatedAt time.Time      `json:"created_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	PlayerID     uint `json:"player_id"`
-	OldTeamID    uint `json:"old_team_id"`
-	NewTeamID    uint `json:"new_team_id"`
-	JerseyNumber int  `json:"jersey_number"`
+	PlayerID     uint   `json:"player_id"`
+	Player       Player `gorm:"foreignKey:PlayerID" json:"-"`
+	OldTeamID    uint   `json:"old_team_id"`
+	OldTeam      Team   `gorm:"foreignKey:OldTeamID" json:"-"`
+	NewTeamID    uint   `json:"new_team_id"`
+	NewTeam      Team   `gorm:"foreignKey:NewTeamID" json:"-"`
+	JerseyNumber int    `json:"jersey_number"`
+
+	Type   string `gorm:"type:ENUM('PERMANEN','PINJAMAN','AKHIR_PINJAMAN');default:'PERMANEN'" json:"type"`
+	LoanID *uint  `gorm:"index" json:"loan_id"`
+
+	TransferWindowID *uint           `json:"transfer_window_id"`
+	TransferWindow   *TransferWindow `gorm:"foreignKey:TransferWindowID" json:"-"`
+	WindowOverride   bool            `gorm:"default:false" json:"window_override"`
 }
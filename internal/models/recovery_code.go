@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type RecoveryCode struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	UserID uint `gorm:"index;not null"`
+	User   User `gorm:"foreignKey:UserID"`
+
+	CodeHash string `gorm:"size:64;unique;not null"`
+	UsedAt   *time.Time
+}
@@ -12,9 +12,14 @@ type RefreshToken struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
-	UserID uint   `gorm:"index"`
-	Token  string `gorm:"size:512;unique;not null"`
-	JTI    string `gorm:"size:128"`
+	UserID    uint `gorm:"index"`
+	SessionID uint `gorm:"index"`
+
+	TokenHash string `gorm:"column:token;size:512;unique;not null"`
+	JTI       string `gorm:"size:128"`
+	FamilyID  string `gorm:"size:128;index"`
 
 	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Season struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	CompetitionID uint        `gorm:"index;not null"`
+	Competition   Competition `gorm:"foreignKey:CompetitionID"`
+
+	Name      string `gorm:"size:100;not null"`
+	StartDate time.Time
+	EndDate   time.Time
+	IsCurrent bool `gorm:"default:false"`
+
+	Matches []Match `gorm:"foreignKey:SeasonID"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SquadRule struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	CompetitionID uint        `gorm:"uniqueIndex;not null"`
+	Competition   Competition `gorm:"foreignKey:CompetitionID"`
+
+	MaxSquadSize      int
+	MaxForeignPlayers int
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StandingRule struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	CompetitionID uint `gorm:"uniqueIndex;not null"`
+
+	PointsWin   int
+	PointsDraw  int
+	PointsLoss  int
+	Tiebreakers string `gorm:"size:512"`
+}
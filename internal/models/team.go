@@ -18,6 +18,9 @@ type Team struct {
 	Address     string `gorm:"size:1024"`
 	City        string `gorm:"size:255"`
 
+	HomeVenueID *uint  `gorm:"index"`
+	HomeVenue   *Venue `gorm:"foreignKey:HomeVenueID"`
+
 	Players []Player `gorm:"foreignKey:TeamID"`
 
 	HomeMatches []Match `gorm:"foreignKey:HomeTeamID"`
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TeamSanction struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	SeasonID uint `gorm:"index;not null"`
+
+	TeamID uint `gorm:"index;not null"`
+	Team   Team `gorm:"foreignKey:TeamID"`
+
+	PointsDeducted int
+	FairPlayPoints int
+	Reason         string `gorm:"size:1024"`
+}
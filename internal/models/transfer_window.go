@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TransferWindow struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	SeasonID uint   `gorm:"index;not null"`
+	Season   Season `gorm:"foreignKey:SeasonID"`
+
+	Name     string `gorm:"size:100;not null"`
+	OpensAt  time.Time
+	ClosesAt time.Time
+}
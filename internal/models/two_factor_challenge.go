@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type TwoFactorChallenge struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+
+	UserID uint `gorm:"index;not null"`
+	User   User `gorm:"foreignKey:UserID"`
+
+	TokenHash  string `gorm:"size:64;unique;not null"`
+	DeviceName string `gorm:"size:255"`
+	Attempts   int    `gorm:"default:0"`
+
+	ExpiresAt time.Time `gorm:"index"`
+	UsedAt    *time.Time
+}
@@ -17,4 +17,8 @@ type User struct {
 	Role         string `gorm:"type:ENUM('ADMIN','STAFF','VIEWER');default:'VIEWER'" json:"role"`
 
 	TokenVersion int `gorm:"default:0"`
+
+	TOTPSecret   string `gorm:"column:totp_secret;size:64" json:"-"`
+	TOTPEnabled  bool   `gorm:"column:totp_enabled;default:false" json:"-"`
+	TOTPLastStep int64  `gorm:"column:totp_last_step;default:0" json:"-"`
 }
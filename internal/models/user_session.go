@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type UserSession struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	UserID uint `gorm:"index;not null"`
+	User   User `gorm:"foreignKey:UserID"`
+
+	DeviceName string `gorm:"size:255"`
+	IPAddress  string `gorm:"size:64"`
+	UserAgent  string `gorm:"size:512"`
+
+	LastUsedAt time.Time
+	ExpiresAt  time.Time `gorm:"index"`
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Venue struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Name      string `gorm:"size:255;unique;not null"`
+	Address   string `gorm:"size:1024"`
+	City      string `gorm:"size:255"`
+	Latitude  *float64
+	Longitude *float64
+	Capacity  int
+	Surface   string `gorm:"type:ENUM('RUMPUT_ALAMI','RUMPUT_SINTETIS','HIBRIDA');default:'RUMPUT_ALAMI'"`
+}
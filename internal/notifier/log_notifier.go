@@ -0,0 +1,20 @@
+package notifier
+
+import "log"
+
+type logNotifier struct {
+	showBody bool
+}
+
+func NewLogNotifier(showBody bool) Notifier {
+	return logNotifier{showBody: showBody}
+}
+
+func (n logNotifier) Send(m Message) error {
+	body := "[redacted]"
+	if n.showBody {
+		body = m.Body
+	}
+	log.Printf("notifier: to=%s user=%d subject=%q body=%q", m.Username, m.UserID, m.Subject, body)
+	return nil
+}
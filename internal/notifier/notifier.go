@@ -0,0 +1,15 @@
+package notifier
+
+import "time"
+
+type Message struct {
+	UserID   uint      `json:"user_id"`
+	Username string    `json:"username"`
+	Subject  string    `json:"subject"`
+	Body     string    `json:"body"`
+	At       time.Time `json:"at"`
+}
+
+type Notifier interface {
+	Send(m Message) error
+}
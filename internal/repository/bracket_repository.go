@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type BracketRepository interface {
+	Create(b *models.Bracket) error
+	GetByID(id uint) (*models.Bracket, error)
+	GetBySeason(seasonID uint) ([]models.Bracket, error)
+	CreateTie(t *models.KnockoutTie) error
+	UpdateTie(t *models.KnockoutTie) error
+	GetTieByID(id uint) (*models.KnockoutTie, error)
+}
+
+type bracketRepository struct {
+	db *gorm.DB
+}
+
+func NewBracketRepository(db *gorm.DB) BracketRepository {
+	return &bracketRepository{db}
+}
+
+func (r *bracketRepository) Create(b *models.Bracket) error {
+	return r.db.Omit("Ties").Create(b).Error
+}
+
+func (r *bracketRepository) GetByID(id uint) (*models.Bracket, error) {
+	var b models.Bracket
+
+	err := r.db.
+		Preload("Season").
+		Preload("Ties", func(db *gorm.DB) *gorm.DB {
+			return db.Order("round ASC, position ASC")
+		}).
+		Preload("Ties.HomeTeam").
+		Preload("Ties.AwayTeam").
+		Preload("Ties.Matches", func(db *gorm.DB) *gorm.DB {
+			return db.Order("leg ASC")
+		}).
+		Preload("Ties.Matches.Goals").
+		Preload("Ties.Matches.PenaltyKicks").
+		First(&b, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *bracketRepository) GetBySeason(seasonID uint) ([]models.Bracket, error) {
+	var list []models.Bracket
+	err := r.db.Where("season_id = ?", seasonID).Order("id ASC").Find(&list).Error
+	return list, err
+}
+
+func (r *bracketRepository) CreateTie(t *models.KnockoutTie) error {
+	return r.db.Omit("Bracket", "HomeTeam", "AwayTeam", "Matches").Create(t).Error
+}
+
+func (r *bracketRepository) UpdateTie(t *models.KnockoutTie) error {
+	return r.db.Omit("Bracket", "HomeTeam", "AwayTeam", "Matches").Save(t).Error
+}
+
+func (r *bracketRepository) GetTieByID(id uint) (*models.KnockoutTie, error) {
+	var t models.KnockoutTie
+
+	err := r.db.
+		Preload("Bracket").
+		Preload("Matches", func(db *gorm.DB) *gorm.DB {
+			return db.Order("leg ASC")
+		}).
+		Preload("Matches.Goals").
+		Preload("Matches.PenaltyKicks").
+		First(&t, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
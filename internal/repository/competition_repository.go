@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"football-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type CompetitionRepository interface {
+	Create(c *models.Competition) error
+	GetAll(q utils.QueryParams) ([]models.Competition, int64, error)
+	GetByID(id uint) (*models.Competition, error)
+	Update(c *models.Competition) error
+	Delete(id uint) error
+}
+
+type competitionRepository struct {
+	db *gorm.DB
+}
+
+func NewCompetitionRepository(db *gorm.DB) CompetitionRepository {
+	return &competitionRepository{db}
+}
+
+func (r *competitionRepository) Create(c *models.Competition) error {
+	return r.db.Create(c).Error
+}
+
+func (r *competitionRepository) GetAll(q utils.QueryParams) ([]models.Competition, int64, error) {
+	var items []models.Competition
+	var total int64
+
+	db := r.db.Model(&models.Competition{})
+
+	db = utils.ApplyFilters(db, q)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db = db.Order(q.Sort + " " + q.Order)
+
+	offset := (q.Page - 1) * q.Limit
+	if err := db.Offset(offset).Limit(q.Limit).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
+
+func (r *competitionRepository) GetByID(id uint) (*models.Competition, error) {
+	var c models.Competition
+
+	err := r.db.
+		Preload("Seasons", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_date DESC")
+		}).
+		First(&c, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *competitionRepository) Update(c *models.Competition) error {
+	return r.db.Omit("Seasons").Save(c).Error
+}
+
+func (r *competitionRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Competition{}, id).Error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type DisciplineRuleRepository interface {
+	GetByCompetition(competitionID uint) (*models.DisciplineRule, error)
+	Save(rule *models.DisciplineRule) error
+}
+
+type disciplineRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewDisciplineRuleRepository(db *gorm.DB) DisciplineRuleRepository {
+	return &disciplineRuleRepository{db}
+}
+
+func (r *disciplineRuleRepository) GetByCompetition(competitionID uint) (*models.DisciplineRule, error) {
+	var rule models.DisciplineRule
+	if err := r.db.Where("competition_id = ?", competitionID).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *disciplineRuleRepository) Save(rule *models.DisciplineRule) error {
+	return r.db.Save(rule).Error
+}
@@ -9,8 +9,11 @@ import (
 
 type GoalRepository interface {
 	AddGoal(g *models.Goal) error
+	Update(g *models.Goal) error
+	Delete(g *models.Goal) error
+	GetByID(id uint) (*models.Goal, error)
 	GetGoals(matchID uint) ([]models.Goal, error)
-	TopScorers(limit int) ([]dto.TopScorerDTO, error)
+	TopScorers(seasonID uint, limit int) ([]dto.TopScorerDTO, error)
 }
 
 type goalRepository struct {
@@ -25,6 +28,24 @@ func (r *goalRepository) AddGoal(g *models.Goal) error {
 	return r.db.Create(g).Error
 }
 
+func (r *goalRepository) Update(g *models.Goal) error {
+	return r.db.Omit("Match", "Team", "Scorer", "Assist").Save(g).Error
+}
+
+func (r *goalRepository) Delete(g *models.Goal) error {
+	return r.db.Delete(g).Error
+}
+
+func (r *goalRepository) GetByID(id uint) (*models.Goal, error) {
+	var goal models.Goal
+
+	err := r.db.First(&goal, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
 func (r *goalRepository) GetGoals(matchID uint) ([]models.Goal, error) {
 	var goals []models.Goal
 
@@ -35,16 +56,17 @@ func (r *goalRepository) GetGoals(matchID uint) ([]models.Goal, error) {
 		Preload("Team").
 		Preload("Scorer").
 		Preload("Scorer.Team").
+		Preload("Assist").
 		Where("match_id = ?", matchID).
 		Find(&goals).Error
 
 	return goals, err
 }
 
-func (r *goalRepository) TopScorers(limit int) ([]dto.TopScorerDTO, error) {
+func (r *goalRepository) TopScorers(seasonID uint, limit int) ([]dto.TopScorerDTO, error) {
 	var result []dto.TopScorerDTO
 
-	err := r.db.Table("goals AS g").
+	db := r.db.Table("goals AS g").
 		Select(`
 			g.scorer_player_id AS player_id,
 			p.name AS player_name,
@@ -54,6 +76,16 @@ func (r *goalRepository) TopScorers(limit int) ([]dto.TopScorerDTO, error) {
 		`).
 		Joins("LEFT JOIN players p ON p.id = g.scorer_player_id").
 		Joins("LEFT JOIN teams t ON t.id = p.team_id").
+		Where("g.deleted_at IS NULL").
+		Where("g.type <> ?", "BUNUH_DIRI")
+
+	if seasonID > 0 {
+		db = db.
+			Joins("JOIN matches m ON m.id = g.match_id").
+			Where("m.season_id = ?", seasonID)
+	}
+
+	err := db.
 		Group("g.scorer_player_id").
 		Order("goals DESC").
 		Limit(limit).
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GroupStageRepository interface {
+	Create(gs *models.GroupStage) error
+	CreateGroup(g *models.TournamentGroup) error
+	GetByID(id uint) (*models.GroupStage, error)
+	GetForUpdate(id uint) (*models.GroupStage, error)
+	GetBySeason(seasonID uint) ([]models.GroupStage, error)
+	Update(gs *models.GroupStage) error
+	CountUnfinishedMatches(stageID uint) (int64, error)
+}
+
+type groupStageRepository struct {
+	db *gorm.DB
+}
+
+func NewGroupStageRepository(db *gorm.DB) GroupStageRepository {
+	return &groupStageRepository{db}
+}
+
+func (r *groupStageRepository) Create(gs *models.GroupStage) error {
+	return r.db.Omit("Season", "Bracket", "Groups").Create(gs).Error
+}
+
+func (r *groupStageRepository) CreateGroup(g *models.TournamentGroup) error {
+	return r.db.Omit("Teams.*", "Matches").Create(g).Error
+}
+
+func (r *groupStageRepository) GetByID(id uint) (*models.GroupStage, error) {
+	var gs models.GroupStage
+
+	err := r.db.
+		Preload("Season").
+		Preload("Groups", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		}).
+		Preload("Groups.Teams").
+		First(&gs, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &gs, nil
+}
+
+func (r *groupStageRepository) GetForUpdate(id uint) (*models.GroupStage, error) {
+	var gs models.GroupStage
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&gs, id).Error; err != nil {
+		return nil, err
+	}
+	return &gs, nil
+}
+
+func (r *groupStageRepository) GetBySeason(seasonID uint) ([]models.GroupStage, error) {
+	var list []models.GroupStage
+	err := r.db.Where("season_id = ?", seasonID).Order("id ASC").Find(&list).Error
+	return list, err
+}
+
+func (r *groupStageRepository) Update(gs *models.GroupStage) error {
+	return r.db.Omit("Season", "Bracket", "Groups").Save(gs).Error
+}
+
+func (r *groupStageRepository) CountUnfinishedMatches(stageID uint) (int64, error) {
+	var count int64
+
+	groupIDs := r.db.Model(&models.TournamentGroup{}).
+		Select("id").
+		Where("group_stage_id = ?", stageID)
+
+	err := r.db.Model(&models.Match{}).
+		Where("group_id IN (?)", groupIDs).
+		Where("status NOT IN ?", []string{"SELESAI", "DIBATALKAN"}).
+		Count(&count).Error
+
+	return count, err
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MatchEventRepository interface {
+	Create(e *models.MatchEvent) error
+	Update(e *models.MatchEvent) error
+	Delete(e *models.MatchEvent) error
+	GetByID(id uint) (*models.MatchEvent, error)
+	GetByGoalID(goalID uint) (*models.MatchEvent, error)
+	GetByMatch(matchID uint) ([]models.MatchEvent, error)
+	GetCardsBySeason(seasonID uint) ([]models.MatchEvent, error)
+	GetPlayerSeasonCards(playerID, seasonID uint, types []string) ([]models.MatchEvent, error)
+}
+
+type matchEventRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchEventRepository(db *gorm.DB) MatchEventRepository {
+	return &matchEventRepository{db}
+}
+
+func (r *matchEventRepository) Create(e *models.MatchEvent) error {
+	return r.db.Omit("Match", "Team", "Player", "RelatedPlayer").Create(e).Error
+}
+
+func (r *matchEventRepository) Update(e *models.MatchEvent) error {
+	return r.db.Omit("Match", "Team", "Player", "RelatedPlayer").Save(e).Error
+}
+
+func (r *matchEventRepository) Delete(e *models.MatchEvent) error {
+	return r.db.Delete(e).Error
+}
+
+func (r *matchEventRepository) GetByID(id uint) (*models.MatchEvent, error) {
+	var event models.MatchEvent
+
+	err := r.db.
+		Preload("Team").
+		Preload("Player").
+		Preload("RelatedPlayer").
+		First(&event, id).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *matchEventRepository) GetByGoalID(goalID uint) (*models.MatchEvent, error) {
+	var event models.MatchEvent
+
+	err := r.db.
+		Where("goal_id = ?", goalID).
+		First(&event).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *matchEventRepository) GetByMatch(matchID uint) ([]models.MatchEvent, error) {
+	var events []models.MatchEvent
+
+	err := r.db.
+		Preload("Team").
+		Preload("Player").
+		Preload("RelatedPlayer").
+		Where("match_id = ?", matchID).
+		Order("id ASC").
+		Find(&events).Error
+
+	return events, err
+}
+
+func (r *matchEventRepository) GetCardsBySeason(seasonID uint) ([]models.MatchEvent, error) {
+	var events []models.MatchEvent
+
+	err := r.db.
+		Joins("JOIN matches m ON m.id = match_events.match_id").
+		Where("m.season_id = ?", seasonID).
+		Where("match_events.type IN ?", []string{"KARTU_KUNING", "KARTU_KUNING_KEDUA", "KARTU_MERAH"}).
+		Find(&events).Error
+
+	return events, err
+}
+
+func (r *matchEventRepository) GetPlayerSeasonCards(playerID, seasonID uint, types []string) ([]models.MatchEvent, error) {
+	var events []models.MatchEvent
+
+	err := r.db.
+		Preload("Match").
+		Joins("JOIN matches m ON m.id = match_events.match_id AND m.deleted_at IS NULL").
+		Where("match_events.player_id = ? AND match_events.type IN ?", playerID, types).
+		Where("m.season_id = ? AND m.status = ?", seasonID, "SELESAI").
+		Order("m.match_date_time ASC, m.id ASC, match_events.id ASC").
+		Find(&events).Error
+
+	return events, err
+}
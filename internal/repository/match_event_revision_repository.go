@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MatchEventRevisionRepository interface {
+	Create(rev *models.MatchEventRevision) error
+	GetByMatch(matchID uint) ([]models.MatchEventRevision, error)
+}
+
+type matchEventRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchEventRevisionRepository(db *gorm.DB) MatchEventRevisionRepository {
+	return &matchEventRevisionRepository{db}
+}
+
+func (r *matchEventRevisionRepository) Create(rev *models.MatchEventRevision) error {
+	return r.db.Omit("ChangedBy").Create(rev).Error
+}
+
+func (r *matchEventRevisionRepository) GetByMatch(matchID uint) ([]models.MatchEventRevision, error) {
+	var revisions []models.MatchEventRevision
+
+	err := r.db.
+		Preload("ChangedBy").
+		Where("match_id = ?", matchID).
+		Order("id DESC").
+		Find(&revisions).Error
+
+	return revisions, err
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MatchLineupRepository interface {
+	Save(l *models.MatchLineup) error
+	GetByMatch(matchID uint) ([]models.MatchLineup, error)
+	GetByMatchTeam(matchID, teamID uint) (*models.MatchLineup, error)
+}
+
+type matchLineupRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchLineupRepository(db *gorm.DB) MatchLineupRepository {
+	return &matchLineupRepository{db}
+}
+
+func (r *matchLineupRepository) Save(l *models.MatchLineup) error {
+	var existing models.MatchLineup
+	err := r.db.Where("match_id = ? AND team_id = ?", l.MatchID, l.TeamID).First(&existing).Error
+	if err == nil {
+		l.ID = existing.ID
+		l.CreatedAt = existing.CreatedAt
+		if err := r.db.Unscoped().Where("lineup_id = ?", existing.ID).Delete(&models.LineupPlayer{}).Error; err != nil {
+			return err
+		}
+	}
+
+	for i := range l.Players {
+		l.Players[i].ID = 0
+		l.Players[i].LineupID = 0
+	}
+
+	return r.db.Omit("Match", "Team", "Captain", "Players.Player").Save(l).Error
+}
+
+func (r *matchLineupRepository) GetByMatch(matchID uint) ([]models.MatchLineup, error) {
+	var lineups []models.MatchLineup
+
+	err := r.db.
+		Preload("Team").
+		Preload("Captain").
+		Preload("Players", func(db *gorm.DB) *gorm.DB {
+			return db.Order("starter DESC, jersey_number ASC")
+		}).
+		Preload("Players.Player").
+		Where("match_id = ?", matchID).
+		Order("id ASC").
+		Find(&lineups).Error
+
+	return lineups, err
+}
+
+func (r *matchLineupRepository) GetByMatchTeam(matchID, teamID uint) (*models.MatchLineup, error) {
+	var lineup models.MatchLineup
+
+	err := r.db.
+		Preload("Players").
+		Where("match_id = ? AND team_id = ?", matchID, teamID).
+		First(&lineup).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &lineup, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"time"
+
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type MatchOfficialRepository interface {
+	GetByMatch(matchID uint) ([]models.MatchOfficial, error)
+	Replace(matchID uint, list []models.MatchOfficial) error
+	FindOverlapping(officialIDs []uint, excludeMatchID uint, from, to time.Time) ([]models.MatchOfficial, error)
+	CountUpcomingByOfficial(officialID uint) (int64, error)
+}
+
+type matchOfficialRepository struct {
+	db *gorm.DB
+}
+
+func NewMatchOfficialRepository(db *gorm.DB) MatchOfficialRepository {
+	return &matchOfficialRepository{db}
+}
+
+func (r *matchOfficialRepository) GetByMatch(matchID uint) ([]models.MatchOfficial, error) {
+	var list []models.MatchOfficial
+
+	err := r.db.
+		Preload("Official").
+		Where("match_id = ?", matchID).
+		Order("FIELD(role, 'WASIT', 'ASISTEN_WASIT_1', 'ASISTEN_WASIT_2', 'OFISIAL_KEEMPAT')").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *matchOfficialRepository) Replace(matchID uint, list []models.MatchOfficial) error {
+	if err := r.db.Where("match_id = ?", matchID).Delete(&models.MatchOfficial{}).Error; err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return nil
+	}
+
+	for i := range list {
+		list[i].ID = 0
+		list[i].MatchID = matchID
+	}
+	return r.db.Omit("Match", "Official").Create(&list).Error
+}
+
+func (r *matchOfficialRepository) FindOverlapping(officialIDs []uint, excludeMatchID uint, from, to time.Time) ([]models.MatchOfficial, error) {
+	var list []models.MatchOfficial
+	if len(officialIDs) == 0 {
+		return list, nil
+	}
+
+	err := r.db.
+		Preload("Match").
+		Preload("Official").
+		Joins("JOIN matches ON matches.id = match_officials.match_id AND matches.deleted_at IS NULL").
+		Where("match_officials.official_id IN ?", officialIDs).
+		Where("match_officials.match_id <> ?", excludeMatchID).
+		Where("matches.status NOT IN ?", []string{"DITUNDA", "DIBATALKAN"}).
+		Where("matches.match_date_time > ? AND matches.match_date_time < ?", from, to).
+		Order("matches.match_date_time ASC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *matchOfficialRepository) CountUpcomingByOfficial(officialID uint) (int64, error) {
+	var total int64
+
+	err := r.db.
+		Model(&models.MatchOfficial{}).
+		Joins("JOIN matches ON matches.id = match_officials.match_id AND matches.deleted_at IS NULL").
+		Where("match_officials.official_id = ?", officialID).
+		Where("matches.status IN ?", []string{"DIJADWALKAN", "SEDANG BERLANGSUNG", "ISTIRAHAT", "DITUNDA"}).
+		Count(&total).Error
+
+	return total, err
+}
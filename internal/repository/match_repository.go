@@ -6,17 +6,29 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MatchRepository interface {
 	Create(m *models.Match) error
 	Update(m *models.Match) error
 	GetByID(id uint) (*models.Match, error)
+	GetForUpdate(id uint) (*models.Match, error)
 	GetAll(q utils.QueryParams) ([]models.Match, int64, error)
 	CountHomeWins(teamID uint) (int64, error)
 	CountAwayWins(teamID uint) (int64, error)
 	CheckConflict(teamID uint, datetime time.Time) (bool, error)
-	GetFinishedMatches() ([]models.Match, error)
+	FindVenueConflict(venueID, excludeMatchID uint, from, to time.Time) (*models.Match, error)
+	GetFinishedMatches(seasonID uint) ([]models.Match, error)
+	GetFinishedMatchesByGroup(groupID uint) ([]models.Match, error)
+	GetTeamIDsBySeason(seasonID uint) ([]uint, error)
+	CountBySeason(seasonID uint) (int64, error)
+	GetByStatuses(statuses []string) ([]models.Match, error)
+	GetFinishedWithoutResult() ([]uint, error)
+	GetFinishedByTeam(teamID, seasonID, competitionID uint) ([]models.Match, error)
+	GetHeadToHead(teamA, teamB uint) ([]models.Match, error)
+	GetTeamMatchesAfter(teamID, competitionID uint, after time.Time, limit int) ([]models.Match, error)
+	GetNextByTeam(teamID uint, after time.Time) (*models.Match, error)
 }
 
 type matchRepository struct {
@@ -39,12 +51,20 @@ func (r *matchRepository) GetByID(id uint) (*models.Match, error) {
 	var match models.Match
 
 	err := r.db.
+		Preload("Season").
+		Preload("Season.Competition").
 		Preload("HomeTeam").
 		Preload("AwayTeam").
+		Preload("Venue").
 		Preload("Goals").
 		Preload("Goals.Team").
 		Preload("Goals.Scorer").
 		Preload("Goals.Scorer.Team").
+		Preload("Goals.Assist").
+		Preload("PenaltyKicks", func(db *gorm.DB) *gorm.DB {
+			return db.Order("kick_order ASC")
+		}).
+		Preload("PenaltyKicks.Player").
 		First(&match, id).Error
 
 	if err != nil {
@@ -53,17 +73,29 @@ func (r *matchRepository) GetByID(id uint) (*models.Match, error) {
 	return &match, nil
 }
 
+func (r *matchRepository) GetForUpdate(id uint) (*models.Match, error) {
+	var match models.Match
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&match, id).Error; err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
 func (r *matchRepository) GetAll(q utils.QueryParams) ([]models.Match, int64, error) {
 	var items []models.Match
 	var total int64
 
 	db := r.db.Model(&models.Match{}).
+		Preload("Season").
+		Preload("Season.Competition").
 		Preload("HomeTeam").
 		Preload("AwayTeam").
+		Preload("Venue").
 		Preload("Goals").
 		Preload("Goals.Team").
 		Preload("Goals.Scorer").
-		Preload("Goals.Scorer.Team")
+		Preload("Goals.Scorer.Team").
+		Preload("Goals.Assist")
 
 	db = utils.ApplyFilters(db, q)
 
@@ -84,7 +116,7 @@ func (r *matchRepository) GetAll(q utils.QueryParams) ([]models.Match, int64, er
 func (r *matchRepository) CountHomeWins(teamID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Match{}).
-		Where("home_team_id = ? AND status = 'HOME_WIN'", teamID).
+		Where("home_team_id = ? AND status = 'SELESAI' AND result = 'HOME_WIN'", teamID).
 		Count(&count).Error
 	return count, err
 }
@@ -92,7 +124,7 @@ func (r *matchRepository) CountHomeWins(teamID uint) (int64, error) {
 func (r *matchRepository) CountAwayWins(teamID uint) (int64, error) {
 	var count int64
 	err := r.db.Model(&models.Match{}).
-		Where("away_team_id = ? AND status = 'AWAY_WIN'", teamID).
+		Where("away_team_id = ? AND status = 'SELESAI' AND result = 'AWAY_WIN'", teamID).
 		Count(&count).Error
 	return count, err
 }
@@ -106,14 +138,197 @@ func (r *matchRepository) CheckConflict(teamID uint, datetime time.Time) (bool,
 	return count > 0, err
 }
 
-func (r *matchRepository) GetFinishedMatches() ([]models.Match, error) {
+func (r *matchRepository) FindVenueConflict(venueID, excludeMatchID uint, from, to time.Time) (*models.Match, error) {
+	var match models.Match
+
+	err := r.db.
+		Preload("HomeTeam").
+		Preload("AwayTeam").
+		Where("venue_id = ? AND id <> ?", venueID, excludeMatchID).
+		Where("status NOT IN ?", []string{"DITUNDA", "DIBATALKAN"}).
+		Where("match_date_time > ? AND match_date_time < ?", from, to).
+		Order("match_date_time ASC").
+		First(&match).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
+
+func (r *matchRepository) GetFinishedMatches(seasonID uint) ([]models.Match, error) {
+	var matches []models.Match
+
+	db := r.db.
+		Preload("Goals").
+		Preload("Goals.Team").
+		Where("status = ?", "SELESAI").
+		Where("tie_id IS NULL").
+		Order("match_date_time ASC")
+
+	if seasonID > 0 {
+		db = db.Where("season_id = ?", seasonID)
+	}
+
+	err := db.Find(&matches).Error
+
+	return matches, err
+}
+
+func (r *matchRepository) GetFinishedMatchesByGroup(groupID uint) ([]models.Match, error) {
+	var matches []models.Match
+
+	err := r.db.
+		Preload("Goals").
+		Preload("Goals.Team").
+		Where("status = ?", "SELESAI").
+		Where("group_id = ?", groupID).
+		Order("match_date_time ASC").
+		Find(&matches).Error
+
+	return matches, err
+}
+
+func (r *matchRepository) GetTeamIDsBySeason(seasonID uint) ([]uint, error) {
+	var homeIDs []uint
+	var awayIDs []uint
+
+	if err := r.db.Model(&models.Match{}).
+		Where("season_id = ? AND tie_id IS NULL", seasonID).
+		Distinct().
+		Pluck("home_team_id", &homeIDs).Error; err != nil {
+		return nil, err
+	}
+
+	if err := r.db.Model(&models.Match{}).
+		Where("season_id = ? AND tie_id IS NULL", seasonID).
+		Distinct().
+		Pluck("away_team_id", &awayIDs).Error; err != nil {
+		return nil, err
+	}
+
+	seen := map[uint]bool{}
+	ids := []uint{}
+	for _, id := range append(homeIDs, awayIDs...) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+func (r *matchRepository) CountBySeason(seasonID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Match{}).
+		Where("season_id = ?", seasonID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *matchRepository) GetByStatuses(statuses []string) ([]models.Match, error) {
+	var matches []models.Match
+
+	err := r.db.
+		Preload("HomeTeam").
+		Preload("AwayTeam").
+		Preload("Goals").
+		Where("status IN ?", statuses).
+		Order("match_date_time ASC").
+		Find(&matches).Error
+
+	return matches, err
+}
+
+func (r *matchRepository) GetFinishedWithoutResult() ([]uint, error) {
+	var ids []uint
+
+	err := r.db.Model(&models.Match{}).
+		Where("status = ? AND result IS NULL", "SELESAI").
+		Order("id ASC").
+		Pluck("id", &ids).Error
+
+	return ids, err
+}
+
+func (r *matchRepository) GetFinishedByTeam(teamID, seasonID, competitionID uint) ([]models.Match, error) {
+	var matches []models.Match
+
+	db := r.db.
+		Preload("HomeTeam").
+		Preload("AwayTeam").
+		Preload("Goals").
+		Where("matches.status = ?", "SELESAI").
+		Where("(matches.home_team_id = ? OR matches.away_team_id = ?)", teamID, teamID).
+		Order("matches.match_date_time ASC")
+
+	if seasonID > 0 {
+		db = db.Where("matches.season_id = ?", seasonID)
+	}
+	if competitionID > 0 {
+		db = db.
+			Joins("JOIN seasons ON seasons.id = matches.season_id").
+			Where("seasons.competition_id = ?", competitionID)
+	}
+
+	err := db.Find(&matches).Error
+
+	return matches, err
+}
+
+func (r *matchRepository) GetHeadToHead(teamA, teamB uint) ([]models.Match, error) {
 	var matches []models.Match
 
 	err := r.db.
+		Preload("Season").
+		Preload("Season.Competition").
+		Preload("HomeTeam").
+		Preload("AwayTeam").
 		Preload("Goals").
 		Preload("Goals.Team").
+		Preload("Goals.Scorer").
+		Preload("Goals.Scorer.Team").
+		Preload("Goals.Assist").
+		Preload("PenaltyKicks").
 		Where("status = ?", "SELESAI").
+		Where("((home_team_id = ? AND away_team_id = ?) OR (home_team_id = ? AND away_team_id = ?))", teamA, teamB, teamB, teamA).
+		Order("match_date_time DESC").
+		Find(&matches).Error
+
+	return matches, err
+}
+
+func (r *matchRepository) GetTeamMatchesAfter(teamID, competitionID uint, after time.Time, limit int) ([]models.Match, error) {
+	var matches []models.Match
+
+	err := r.db.
+		Joins("JOIN seasons ON seasons.id = matches.season_id").
+		Where("seasons.competition_id = ?", competitionID).
+		Where("(matches.home_team_id = ? OR matches.away_team_id = ?)", teamID, teamID).
+		Where("matches.match_date_time > ?", after).
+		Where("matches.status NOT IN ?", []string{"DITUNDA", "DIHENTIKAN", "DIBATALKAN"}).
+		Order("matches.match_date_time ASC, matches.id ASC").
+		Limit(limit).
 		Find(&matches).Error
 
 	return matches, err
 }
+
+func (r *matchRepository) GetNextByTeam(teamID uint, after time.Time) (*models.Match, error) {
+	var match models.Match
+
+	err := r.db.
+		Preload("Season").
+		Preload("HomeTeam").
+		Preload("AwayTeam").
+		Where("(home_team_id = ? OR away_team_id = ?)", teamID, teamID).
+		Where("status = ? AND match_date_time >= ?", "DIJADWALKAN", after).
+		Order("match_date_time ASC").
+		First(&match).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &match, nil
+}
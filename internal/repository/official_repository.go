@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+	"football-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type OfficialStatFilter struct {
+	SeasonID      uint
+	CompetitionID uint
+}
+
+type OfficialRepository interface {
+	Create(o *models.Official) error
+	GetAll(q utils.QueryParams) ([]models.Official, int64, error)
+	GetByID(id uint) (*models.Official, error)
+	Update(o *models.Official) error
+	Delete(id uint) error
+	Stats(officialID uint, f OfficialStatFilter) (*dto.OfficialStatDTO, error)
+}
+
+type officialRepository struct {
+	db *gorm.DB
+}
+
+func NewOfficialRepository(db *gorm.DB) OfficialRepository {
+	return &officialRepository{db}
+}
+
+func (r *officialRepository) Create(o *models.Official) error {
+	return r.db.Create(o).Error
+}
+
+func (r *officialRepository) GetAll(q utils.QueryParams) ([]models.Official, int64, error) {
+	var officials []models.Official
+	var total int64
+
+	db := r.db.Model(&models.Official{})
+
+	db = utils.ApplyFilters(db, q)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db = db.Order(q.Sort + " " + q.Order)
+
+	offset := (q.Page - 1) * q.Limit
+	if err := db.Offset(offset).Limit(q.Limit).Find(&officials).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return officials, total, nil
+}
+
+func (r *officialRepository) GetByID(id uint) (*models.Official, error) {
+	var o models.Official
+
+	if err := r.db.First(&o, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+func (r *officialRepository) Update(o *models.Official) error {
+	return r.db.Omit("Assignments").Save(o).Error
+}
+
+func (r *officialRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Official{}, id).Error
+}
+
+func (r *officialRepository) Stats(officialID uint, f OfficialStatFilter) (*dto.OfficialStatDTO, error) {
+	scope, args := matchScope("mo", PlayerStatFilter{SeasonID: f.SeasonID, CompetitionID: f.CompetitionID})
+
+	sql := `
+		SELECT
+			COUNT(*) AS matches,
+			COALESCE(SUM(mo.role = 'WASIT'), 0) AS matches_as_referee,
+			COALESCE(SUM(mo.role IN ('ASISTEN_WASIT_1', 'ASISTEN_WASIT_2')), 0) AS matches_as_assistant,
+			COALESCE(SUM(mo.role = 'OFISIAL_KEEMPAT'), 0) AS matches_as_fourth_official,
+			COALESCE(SUM(CASE WHEN mo.role = 'WASIT' THEN ev.yellow_cards END), 0) AS yellow_cards,
+			COALESCE(SUM(CASE WHEN mo.role = 'WASIT' THEN ev.second_yellow_cards END), 0) AS second_yellow_cards,
+			COALESCE(SUM(CASE WHEN mo.role = 'WASIT' THEN ev.red_cards END), 0) AS red_cards,
+			COALESCE(SUM(CASE WHEN mo.role = 'WASIT' THEN ev.penalties_awarded END), 0) AS penalties_awarded
+		FROM match_officials mo
+		LEFT JOIN (
+			SELECT
+				e.match_id,
+				SUM(e.type = 'KARTU_KUNING') AS yellow_cards,
+				SUM(e.type = 'KARTU_KUNING_KEDUA') AS second_yellow_cards,
+				SUM(e.type = 'KARTU_MERAH') AS red_cards,
+				SUM(e.type IN ('GOL_PENALTI', 'PENALTI_GAGAL')) AS penalties_awarded
+			FROM match_events e
+			WHERE e.deleted_at IS NULL
+			GROUP BY e.match_id
+		) ev ON ev.match_id = mo.match_id` + scope + `
+		AND mo.official_id = ?`
+	args = append(args, officialID)
+
+	var result dto.OfficialStatDTO
+	if err := r.db.Raw(sql, args...).Scan(&result).Error; err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	Create(t *models.PasswordResetToken) error
+	Get(tokenHash string) (*models.PasswordResetToken, error)
+	MarkUsed(id uint, at time.Time) (bool, error)
+	InvalidateByUser(userID uint, at time.Time) error
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db}
+}
+
+func (r *passwordResetRepository) Create(t *models.PasswordResetToken) error {
+	return r.db.Omit("User").Create(t).Error
+}
+
+func (r *passwordResetRepository) Get(tokenHash string) (*models.PasswordResetToken, error) {
+	var t models.PasswordResetToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&t).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *passwordResetRepository) MarkUsed(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *passwordResetRepository) InvalidateByUser(userID uint, at time.Time) error {
+	return r.db.Model(&models.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", at).Error
+}
+
+func (r *passwordResetRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
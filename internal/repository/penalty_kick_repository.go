@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type PenaltyKickRepository interface {
+	ReplaceForMatch(matchID uint, kicks []models.PenaltyKick) error
+	GetByMatch(matchID uint) ([]models.PenaltyKick, error)
+}
+
+type penaltyKickRepository struct {
+	db *gorm.DB
+}
+
+func NewPenaltyKickRepository(db *gorm.DB) PenaltyKickRepository {
+	return &penaltyKickRepository{db}
+}
+
+func (r *penaltyKickRepository) ReplaceForMatch(matchID uint, kicks []models.PenaltyKick) error {
+	if err := r.db.Where("match_id = ?", matchID).Delete(&models.PenaltyKick{}).Error; err != nil {
+		return err
+	}
+	if len(kicks) == 0 {
+		return nil
+	}
+	return r.db.Omit("Match", "Team", "Player").Create(&kicks).Error
+}
+
+func (r *penaltyKickRepository) GetByMatch(matchID uint) ([]models.PenaltyKick, error) {
+	var kicks []models.PenaltyKick
+
+	err := r.db.
+		Preload("Team").
+		Preload("Player").
+		Where("match_id = ?", matchID).
+		Order("kick_order ASC").
+		Find(&kicks).Error
+
+	return kicks, err
+}
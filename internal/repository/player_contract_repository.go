@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlayerContractRepository interface {
+	Create(c *models.PlayerContract) error
+	Update(c *models.PlayerContract) error
+	GetByPlayer(playerID uint) ([]models.PlayerContract, error)
+	FindCurrent(playerID uint) (*models.PlayerContract, error)
+	TerminateOpen(playerID uint, at time.Time) error
+	GetExpiring(from, to time.Time, teamID uint) ([]models.PlayerContract, error)
+}
+
+type playerContractRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayerContractRepository(db *gorm.DB) PlayerContractRepository {
+	return &playerContractRepository{db}
+}
+
+func (r *playerContractRepository) Create(c *models.PlayerContract) error {
+	return r.db.Omit("Player", "Team").Create(c).Error
+}
+
+func (r *playerContractRepository) Update(c *models.PlayerContract) error {
+	return r.db.Omit("Player", "Team").Save(c).Error
+}
+
+func (r *playerContractRepository) preload() *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+
+	return r.db.
+		Preload("Player", unscoped).
+		Preload("Team", unscoped)
+}
+
+func (r *playerContractRepository) GetByPlayer(playerID uint) ([]models.PlayerContract, error) {
+	var list []models.PlayerContract
+
+	err := r.preload().
+		Where("player_id = ?", playerID).
+		Order("start_date DESC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerContractRepository) FindCurrent(playerID uint) (*models.PlayerContract, error) {
+	var c models.PlayerContract
+
+	err := r.preload().
+		Where("player_id = ? AND terminated_at IS NULL", playerID).
+		Order("end_date DESC").
+		First(&c).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *playerContractRepository) TerminateOpen(playerID uint, at time.Time) error {
+	return r.db.Model(&models.PlayerContract{}).
+		Where("player_id = ? AND terminated_at IS NULL", playerID).
+		Update("terminated_at", at).Error
+}
+
+func (r *playerContractRepository) GetExpiring(from, to time.Time, teamID uint) ([]models.PlayerContract, error) {
+	var list []models.PlayerContract
+
+	db := r.preload().
+		Joins("JOIN players ON players.id = player_contracts.player_id AND players.deleted_at IS NULL").
+		Where("player_contracts.terminated_at IS NULL").
+		Where("player_contracts.end_date BETWEEN ? AND ?", from, to)
+
+	if teamID > 0 {
+		db = db.Where("player_contracts.team_id = ?", teamID)
+	}
+
+	err := db.Order("player_contracts.end_date ASC").Find(&list).Error
+	return list, err
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlayerInjuryRepository interface {
+	Create(i *models.PlayerInjury) error
+	Update(i *models.PlayerInjury) error
+	GetByID(id uint) (*models.PlayerInjury, error)
+	GetByPlayer(playerID uint) ([]models.PlayerInjury, error)
+	GetActiveByTeam(teamID uint, at time.Time) ([]models.PlayerInjury, error)
+}
+
+type playerInjuryRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayerInjuryRepository(db *gorm.DB) PlayerInjuryRepository {
+	return &playerInjuryRepository{db}
+}
+
+func (r *playerInjuryRepository) Create(i *models.PlayerInjury) error {
+	return r.db.Omit("Player").Create(i).Error
+}
+
+func (r *playerInjuryRepository) Update(i *models.PlayerInjury) error {
+	return r.db.Omit("Player").Save(i).Error
+}
+
+func (r *playerInjuryRepository) GetByID(id uint) (*models.PlayerInjury, error) {
+	var i models.PlayerInjury
+	if err := r.db.Preload("Player").First(&i, id).Error; err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+func (r *playerInjuryRepository) GetByPlayer(playerID uint) ([]models.PlayerInjury, error) {
+	var list []models.PlayerInjury
+
+	err := r.db.
+		Preload("Player").
+		Where("player_id = ?", playerID).
+		Order("start_date DESC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerInjuryRepository) GetActiveByTeam(teamID uint, at time.Time) ([]models.PlayerInjury, error) {
+	var list []models.PlayerInjury
+
+	err := r.db.
+		Preload("Player").
+		Joins("JOIN players ON players.id = player_injuries.player_id AND players.deleted_at IS NULL").
+		Where("players.team_id = ?", teamID).
+		Where("player_injuries.start_date <= ?", at).
+		Where("((player_injuries.returned_at IS NULL AND player_injuries.expected_return > ?) OR player_injuries.returned_at > ?)", at, at).
+		Order("player_injuries.expected_return ASC").
+		Find(&list).Error
+
+	return list, err
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PlayerLoanRepository interface {
+	Create(l *models.PlayerLoan) error
+	Update(l *models.PlayerLoan) error
+	GetByID(id uint) (*models.PlayerLoan, error)
+	GetForUpdate(id uint) (*models.PlayerLoan, error)
+	GetByPlayer(playerID uint) ([]models.PlayerLoan, error)
+	FindOpenByPlayer(playerID uint) (*models.PlayerLoan, error)
+	GetDueToStart(at time.Time) ([]models.PlayerLoan, error)
+	GetDueToEnd(at time.Time) ([]models.PlayerLoan, error)
+}
+
+type playerLoanRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayerLoanRepository(db *gorm.DB) PlayerLoanRepository {
+	return &playerLoanRepository{db}
+}
+
+func (r *playerLoanRepository) Create(l *models.PlayerLoan) error {
+	return r.db.Omit("Player", "ParentTeam", "LoanTeam").Create(l).Error
+}
+
+func (r *playerLoanRepository) Update(l *models.PlayerLoan) error {
+	return r.db.Omit("Player", "ParentTeam", "LoanTeam").Save(l).Error
+}
+
+func (r *playerLoanRepository) preload() *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+
+	return r.db.
+		Preload("Player", unscoped).
+		Preload("ParentTeam", unscoped).
+		Preload("LoanTeam", unscoped)
+}
+
+func (r *playerLoanRepository) GetByID(id uint) (*models.PlayerLoan, error) {
+	var l models.PlayerLoan
+	if err := r.preload().First(&l, id).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *playerLoanRepository) GetForUpdate(id uint) (*models.PlayerLoan, error) {
+	var l models.PlayerLoan
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&l, id).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *playerLoanRepository) GetByPlayer(playerID uint) ([]models.PlayerLoan, error) {
+	var list []models.PlayerLoan
+
+	err := r.preload().
+		Where("player_id = ?", playerID).
+		Order("start_date DESC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerLoanRepository) FindOpenByPlayer(playerID uint) (*models.PlayerLoan, error) {
+	var l models.PlayerLoan
+
+	err := r.db.
+		Where("player_id = ? AND status IN ?", playerID, []string{"DIJADWALKAN", "AKTIF"}).
+		First(&l).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *playerLoanRepository) GetDueToStart(at time.Time) ([]models.PlayerLoan, error) {
+	var list []models.PlayerLoan
+
+	err := r.db.
+		Where("status = ? AND start_date <= ?", "DIJADWALKAN", at).
+		Order("start_date ASC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerLoanRepository) GetDueToEnd(at time.Time) ([]models.PlayerLoan, error) {
+	var list []models.PlayerLoan
+
+	err := r.db.
+		Where("status = ? AND end_date <= ?", "AKTIF", at).
+		Order("end_date ASC").
+		Find(&list).Error
+
+	return list, err
+}
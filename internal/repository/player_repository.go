@@ -15,6 +15,8 @@ type PlayerRepository interface {
 	GetByID(id uint) (*models.Player, error)
 	GetByTeam(teamID uint) ([]models.Player, error)
 	FindJerseyNumber(teamID uint, jerseyNumber int) (*models.Player, error)
+	CountByTeam(teamID, excludeID uint) (int64, error)
+	CountForeignByTeam(teamID, excludeID uint, country string) (int64, error)
 }
 
 type playerRepository struct {
@@ -30,7 +32,7 @@ func (r *playerRepository) Create(p *models.Player) error {
 }
 
 func (r *playerRepository) Update(p *models.Player) error {
-	return r.db.Save(p).Error
+	return r.db.Omit("Team").Save(p).Error
 }
 
 func (r *playerRepository) Delete(id uint) error {
@@ -84,3 +86,18 @@ func (r *playerRepository) FindJerseyNumber(teamID uint, jerseyNumber int) (*mod
 	err := r.db.Where("team_id = ? AND jersey_number = ?", teamID, jerseyNumber).First(&p).Error
 	return &p, err
 }
+
+func (r *playerRepository) CountByTeam(teamID, excludeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Player{}).Where("team_id = ? AND id <> ?", teamID, excludeID).Count(&count).Error
+	return count, err
+}
+
+func (r *playerRepository) CountForeignByTeam(teamID, excludeID uint, country string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Player{}).
+		Where("team_id = ? AND id <> ?", teamID, excludeID).
+		Where("nationality <> '' AND LOWER(nationality) <> LOWER(?)", country).
+		Count(&count).Error
+	return count, err
+}
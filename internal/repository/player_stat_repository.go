@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"football-backend/internal/dto"
+
+	"gorm.io/gorm"
+)
+
+type PlayerStatFilter struct {
+	PlayerID      uint
+	SeasonID      uint
+	CompetitionID uint
+	MinMinutes    int
+}
+
+type PlayerStatRepository interface {
+	GetStats(f PlayerStatFilter) ([]dto.PlayerStatDTO, error)
+	Leaderboard(metric string, f PlayerStatFilter, limit int) ([]dto.PlayerStatDTO, error)
+}
+
+type playerStatRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayerStatRepository(db *gorm.DB) PlayerStatRepository {
+	return &playerStatRepository{db}
+}
+
+func matchScope(alias string, f PlayerStatFilter) (string, []interface{}) {
+	sql := `
+		JOIN matches m ON m.id = ` + alias + `.match_id
+		LEFT JOIN seasons sn ON sn.id = m.season_id
+		WHERE m.deleted_at IS NULL AND m.status = 'SELESAI'`
+	args := []interface{}{}
+
+	if f.SeasonID > 0 {
+		sql += " AND m.season_id = ?"
+		args = append(args, f.SeasonID)
+	}
+	if f.CompetitionID > 0 {
+		sql += " AND sn.competition_id = ?"
+		args = append(args, f.CompetitionID)
+	}
+	return sql, args
+}
+
+func minuteBaseSQL(column string) string {
+	return "CAST(SUBSTRING_INDEX(" + column + ", '+', 1) AS UNSIGNED)"
+}
+
+func playerStatsQuery(f PlayerStatFilter) (string, []interface{}) {
+	args := []interface{}{}
+
+	appScope, appArgs := matchScope("x", f)
+	goalScope, goalArgs := matchScope("g", f)
+	assistScope, assistArgs := matchScope("g", f)
+	eventScope, eventArgs := matchScope("e", f)
+
+	sql := `
+		SELECT
+			p.id AS player_id,
+			p.name AS player_name,
+			p.team_id AS team_id,
+			t.name AS team_name,
+			COALESCE(a.appearances, 0) AS appearances,
+			COALESCE(a.minutes, 0) AS minutes,
+			COALESCE(gl.goals, 0) AS goals,
+			COALESCE(ast.assists, 0) AS assists,
+			COALESCE(gl.penalty_goals, 0) AS penalty_goals,
+			COALESCE(ev.penalties_missed, 0) AS penalties_missed,
+			COALESCE(ev.yellow_cards, 0) AS yellow_cards,
+			COALESCE(ev.red_cards, 0) AS red_cards
+		FROM players p
+		LEFT JOIN teams t ON t.id = p.team_id
+		LEFT JOIN (
+			SELECT ap.player_id, COUNT(*) AS appearances, SUM(GREATEST(ap.end_minute - ap.start_minute, 0)) AS minutes
+			FROM (
+				SELECT
+					x.player_id,
+					x.start_minute,
+					COALESCE((
+						SELECT MIN(` + minuteBaseSQL("o.minute") + `)
+						FROM match_events o
+						WHERE o.match_id = x.match_id AND o.deleted_at IS NULL AND (
+							(o.type = 'PERGANTIAN' AND o.related_player_id = x.player_id) OR
+							(o.type IN ('KARTU_MERAH', 'KARTU_KUNING_KEDUA') AND o.player_id = x.player_id)
+						)
+					), CASE WHEN m.extra_time THEN 120 ELSE 90 END) AS end_minute
+				FROM (
+					SELECT lp.player_id, ml.match_id, 0 AS start_minute
+					FROM lineup_players lp
+					JOIN match_lineups ml ON ml.id = lp.lineup_id AND ml.deleted_at IS NULL
+					WHERE lp.starter = TRUE AND lp.deleted_at IS NULL
+					UNION ALL
+					SELECT se.player_id, se.match_id, ` + minuteBaseSQL("se.minute") + ` AS start_minute
+					FROM match_events se
+					WHERE se.type = 'PERGANTIAN' AND se.deleted_at IS NULL
+				) x` + appScope + `
+			) ap
+			GROUP BY ap.player_id
+		) a ON a.player_id = p.id
+		LEFT JOIN (
+			SELECT g.scorer_player_id AS player_id,
+				SUM(g.type <> 'BUNUH_DIRI') AS goals,
+				SUM(g.type = 'PENALTI') AS penalty_goals
+			FROM goals g` + goalScope + ` AND g.deleted_at IS NULL
+			GROUP BY g.scorer_player_id
+		) gl ON gl.player_id = p.id
+		LEFT JOIN (
+			SELECT g.assist_player_id AS player_id, COUNT(*) AS assists
+			FROM goals g` + assistScope + ` AND g.deleted_at IS NULL AND g.assist_player_id IS NOT NULL
+			GROUP BY g.assist_player_id
+		) ast ON ast.player_id = p.id
+		LEFT JOIN (
+			SELECT e.player_id,
+				SUM(e.type = 'PENALTI_GAGAL') AS penalties_missed,
+				SUM(e.type IN ('KARTU_KUNING', 'KARTU_KUNING_KEDUA')) AS yellow_cards,
+				SUM(e.type IN ('KARTU_MERAH', 'KARTU_KUNING_KEDUA')) AS red_cards
+			FROM match_events e` + eventScope + ` AND e.deleted_at IS NULL
+			GROUP BY e.player_id
+		) ev ON ev.player_id = p.id
+		WHERE p.deleted_at IS NULL`
+
+	args = append(args, appArgs...)
+	args = append(args, goalArgs...)
+	args = append(args, assistArgs...)
+	args = append(args, eventArgs...)
+
+	if f.PlayerID > 0 {
+		sql += " AND p.id = ?"
+		args = append(args, f.PlayerID)
+	}
+
+	sql = `
+		SELECT s.*, CASE WHEN s.minutes > 0 THEN s.goals * 90 / s.minutes ELSE 0 END AS goals_per_90
+		FROM (` + sql + `) s
+		WHERE s.minutes >= ?`
+	args = append(args, f.MinMinutes)
+
+	return sql, args
+}
+
+func (r *playerStatRepository) GetStats(f PlayerStatFilter) ([]dto.PlayerStatDTO, error) {
+	var result []dto.PlayerStatDTO
+
+	sql, args := playerStatsQuery(f)
+	err := r.db.Raw(sql, args...).Scan(&result).Error
+
+	return result, err
+}
+
+func (r *playerStatRepository) Leaderboard(metric string, f PlayerStatFilter, limit int) ([]dto.PlayerStatDTO, error) {
+	var result []dto.PlayerStatDTO
+
+	sql, args := playerStatsQuery(f)
+	sql = "SELECT * FROM (" + sql + ") board WHERE board." + metric + " > 0 ORDER BY board." + metric + " DESC, board.minutes ASC, board.player_id ASC LIMIT ?"
+	args = append(args, limit)
+
+	err := r.db.Raw(sql, args...).Scan(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PlayerSuspensionRepository interface {
+	Create(s *models.PlayerSuspension) error
+	Delete(id uint) error
+	GetByID(id uint) (*models.PlayerSuspension, error)
+	GetByPlayer(playerID uint) ([]models.PlayerSuspension, error)
+	GetByPlayerCompetition(playerID, competitionID uint, before time.Time) ([]models.PlayerSuspension, error)
+	GetByTeamCompetition(teamID, competitionID uint, before time.Time) ([]models.PlayerSuspension, error)
+	DeleteAutoByMatch(matchID uint) error
+	GetAutoByMatch(matchID uint, reason string) ([]models.PlayerSuspension, error)
+	DeleteAutoBySeason(playerID, seasonID uint, reason string) error
+}
+
+type playerSuspensionRepository struct {
+	db *gorm.DB
+}
+
+func NewPlayerSuspensionRepository(db *gorm.DB) PlayerSuspensionRepository {
+	return &playerSuspensionRepository{db}
+}
+
+func (r *playerSuspensionRepository) Create(s *models.PlayerSuspension) error {
+	return r.db.Omit("Player", "Competition").Create(s).Error
+}
+
+func (r *playerSuspensionRepository) Delete(id uint) error {
+	return r.db.Delete(&models.PlayerSuspension{}, id).Error
+}
+
+func (r *playerSuspensionRepository) GetByID(id uint) (*models.PlayerSuspension, error) {
+	var s models.PlayerSuspension
+	if err := r.db.Preload("Player").Preload("Competition").First(&s, id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *playerSuspensionRepository) GetByPlayer(playerID uint) ([]models.PlayerSuspension, error) {
+	var list []models.PlayerSuspension
+
+	err := r.db.
+		Preload("Player").
+		Preload("Competition").
+		Where("player_id = ?", playerID).
+		Order("starts_at DESC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerSuspensionRepository) GetByPlayerCompetition(playerID, competitionID uint, before time.Time) ([]models.PlayerSuspension, error) {
+	var list []models.PlayerSuspension
+
+	err := r.db.
+		Preload("Player").
+		Preload("Competition").
+		Where("player_id = ? AND competition_id = ? AND starts_at < ?", playerID, competitionID, before).
+		Order("starts_at ASC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerSuspensionRepository) GetByTeamCompetition(teamID, competitionID uint, before time.Time) ([]models.PlayerSuspension, error) {
+	var list []models.PlayerSuspension
+
+	err := r.db.
+		Preload("Player").
+		Preload("Competition").
+		Joins("JOIN players ON players.id = player_suspensions.player_id AND players.deleted_at IS NULL").
+		Where("players.team_id = ?", teamID).
+		Where("player_suspensions.competition_id = ? AND player_suspensions.starts_at < ?", competitionID, before).
+		Order("player_suspensions.starts_at ASC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerSuspensionRepository) DeleteAutoByMatch(matchID uint) error {
+	return r.db.
+		Where("source_match_id = ? AND reason <> ?", matchID, "MANUAL").
+		Delete(&models.PlayerSuspension{}).Error
+}
+
+func (r *playerSuspensionRepository) GetAutoByMatch(matchID uint, reason string) ([]models.PlayerSuspension, error) {
+	var list []models.PlayerSuspension
+
+	err := r.db.
+		Where("source_match_id = ? AND reason = ?", matchID, reason).
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerSuspensionRepository) DeleteAutoBySeason(playerID, seasonID uint, reason string) error {
+	return r.db.
+		Where("player_id = ? AND reason = ?", playerID, reason).
+		Where("source_match_id IN (SELECT id FROM matches WHERE season_id = ?)", seasonID).
+		Delete(&models.PlayerSuspension{}).Error
+}
@@ -8,6 +8,8 @@ import (
 
 type PlayerTransferRepository interface {
 	Create(t *models.PlayerTransfer) error
+	GetByPlayer(playerID uint) ([]models.PlayerTransfer, error)
+	GetByTeam(teamID uint) ([]models.PlayerTransfer, error)
 }
 
 type playerTransferRepository struct {
@@ -19,5 +21,39 @@ func NewPlayerTransferRepository(db *gorm.DB) PlayerTransferRepository {
 }
 
 func (r *playerTransferRepository) Create(t *models.PlayerTransfer) error {
-	return r.db.Create(t).Error
+	return r.db.Omit("Player", "OldTeam", "NewTeam", "TransferWindow").Create(t).Error
+}
+
+func (r *playerTransferRepository) preload() *gorm.DB {
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+
+	return r.db.
+		Preload("Player", unscoped).
+		Preload("OldTeam", unscoped).
+		Preload("NewTeam", unscoped).
+		Preload("TransferWindow")
+}
+
+func (r *playerTransferRepository) GetByPlayer(playerID uint) ([]models.PlayerTransfer, error) {
+	var list []models.PlayerTransfer
+
+	err := r.preload().
+		Where("player_id = ?", playerID).
+		Order("created_at DESC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *playerTransferRepository) GetByTeam(teamID uint) ([]models.PlayerTransfer, error) {
+	var list []models.PlayerTransfer
+
+	err := r.preload().
+		Where("old_team_id = ? OR new_team_id = ?", teamID, teamID).
+		Order("created_at DESC").
+		Find(&list).Error
+
+	return list, err
 }
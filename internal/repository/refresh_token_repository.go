@@ -8,10 +8,16 @@ import (
 )
 
 type RefreshTokenRepository interface {
-	Save(userID uint, token string, jti string, expiresAt time.Time) error
-	Get(token string) (*models.RefreshToken, error)
-	Delete(token string) error
-	DeleteByUser(userID uint) error
+	Save(rt *models.RefreshToken) error
+	Get(tokenHash string) (*models.RefreshToken, error)
+	MarkUsed(id uint, at time.Time) (bool, error)
+	RevokeFamily(familyID string, at time.Time) error
+	Delete(tokenHash string) error
+	RevokeByUser(userID uint, at time.Time) error
+	DeleteBySession(sessionID uint) error
+	DeleteByUserExceptSession(userID, sessionID uint) error
+	DeleteExpired(before time.Time) (int64, error)
+	HashLegacyTokens(at time.Time) (int64, error)
 }
 
 type refreshTokenRepo struct {
@@ -22,29 +28,61 @@ func NewRefreshTokenRepository(db *gorm.DB) RefreshTokenRepository {
 	return &refreshTokenRepo{db}
 }
 
-func (r *refreshTokenRepo) Save(userID uint, token, jti string, expiresAt time.Time) error {
-	rt := models.RefreshToken{
-		UserID:    userID,
-		Token:     token,
-		JTI:       jti,
-		ExpiresAt: expiresAt,
-	}
-	return r.db.Create(&rt).Error
+func (r *refreshTokenRepo) Save(rt *models.RefreshToken) error {
+	return r.db.Create(rt).Error
 }
 
-func (r *refreshTokenRepo) Get(token string) (*models.RefreshToken, error) {
+func (r *refreshTokenRepo) Get(tokenHash string) (*models.RefreshToken, error) {
 	var rt models.RefreshToken
-	err := r.db.Where("token = ?", token).First(&rt).Error
+	err := r.db.Where("token = ?", tokenHash).First(&rt).Error
 	if err != nil {
 		return nil, err
 	}
 	return &rt, nil
 }
 
-func (r *refreshTokenRepo) Delete(token string) error {
-	return r.db.Where("token = ?", token).Delete(&models.RefreshToken{}).Error
+func (r *refreshTokenRepo) MarkUsed(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *refreshTokenRepo) RevokeFamily(familyID string, at time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", at).Error
+}
+
+func (r *refreshTokenRepo) Delete(tokenHash string) error {
+	return r.db.Where("token = ?", tokenHash).Delete(&models.RefreshToken{}).Error
+}
+
+func (r *refreshTokenRepo) RevokeByUser(userID uint, at time.Time) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+func (r *refreshTokenRepo) DeleteBySession(sessionID uint) error {
+	return r.db.Where("session_id = ?", sessionID).Delete(&models.RefreshToken{}).Error
+}
+
+func (r *refreshTokenRepo) DeleteByUserExceptSession(userID, sessionID uint) error {
+	return r.db.Where("user_id = ? AND session_id <> ?", userID, sessionID).Delete(&models.RefreshToken{}).Error
+}
+
+func (r *refreshTokenRepo) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Unscoped().Where("expires_at < ?", before).Delete(&models.RefreshToken{})
+	return result.RowsAffected, result.Error
 }
 
-func (r *refreshTokenRepo) DeleteByUser(userID uint) error {
-	return r.db.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error
+func (r *refreshTokenRepo) HashLegacyTokens(at time.Time) (int64, error) {
+	result := r.db.Unscoped().Model(&models.RefreshToken{}).
+		Where("CHAR_LENGTH(token) <> 64").
+		Updates(map[string]interface{}{
+			"token":      gorm.Expr("SHA2(token, 256)"),
+			"revoked_at": gorm.Expr("COALESCE(revoked_at, ?)", at),
+		})
+	return result.RowsAffected, result.Error
 }
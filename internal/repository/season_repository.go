@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SeasonRepository interface {
+	Create(s *models.Season) error
+	GetByID(id uint) (*models.Season, error)
+	GetByCompetition(competitionID uint) ([]models.Season, error)
+	Update(s *models.Season) error
+	Delete(id uint) error
+	ClearCurrent(competitionID uint, exceptID uint) error
+}
+
+type seasonRepository struct {
+	db *gorm.DB
+}
+
+func NewSeasonRepository(db *gorm.DB) SeasonRepository {
+	return &seasonRepository{db}
+}
+
+func (r *seasonRepository) Create(s *models.Season) error {
+	return r.db.Create(s).Error
+}
+
+func (r *seasonRepository) GetByID(id uint) (*models.Season, error) {
+	var s models.Season
+	if err := r.db.Preload("Competition").First(&s, id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *seasonRepository) GetByCompetition(competitionID uint) ([]models.Season, error) {
+	var seasons []models.Season
+
+	err := r.db.
+		Preload("Competition").
+		Where("competition_id = ?", competitionID).
+		Order("start_date DESC").
+		Find(&seasons).Error
+
+	return seasons, err
+}
+
+func (r *seasonRepository) Update(s *models.Season) error {
+	return r.db.Omit("Competition", "Matches").Save(s).Error
+}
+
+func (r *seasonRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Season{}, id).Error
+}
+
+func (r *seasonRepository) ClearCurrent(competitionID uint, exceptID uint) error {
+	return r.db.Model(&models.Season{}).
+		Where("competition_id = ? AND id <> ?", competitionID, exceptID).
+		Update("is_current", false).Error
+}
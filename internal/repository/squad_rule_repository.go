@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type SquadRuleRepository interface {
+	GetByCompetition(competitionID uint) (*models.SquadRule, error)
+	Save(rule *models.SquadRule) error
+	GetForTeam(teamID uint) ([]models.SquadRule, error)
+}
+
+type squadRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewSquadRuleRepository(db *gorm.DB) SquadRuleRepository {
+	return &squadRuleRepository{db}
+}
+
+func (r *squadRuleRepository) GetByCompetition(competitionID uint) (*models.SquadRule, error) {
+	var rule models.SquadRule
+	if err := r.db.Where("competition_id = ?", competitionID).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *squadRuleRepository) Save(rule *models.SquadRule) error {
+	return r.db.Omit("Competition").Save(rule).Error
+}
+
+func (r *squadRuleRepository) GetForTeam(teamID uint) ([]models.SquadRule, error) {
+	var list []models.SquadRule
+
+	err := r.db.
+		Preload("Competition").
+		Where(`competition_id IN (
+			SELECT s.competition_id FROM seasons s
+			WHERE s.is_current = ? AND s.deleted_at IS NULL AND (
+				EXISTS (
+					SELECT 1 FROM matches m
+					WHERE m.season_id = s.id AND m.deleted_at IS NULL
+						AND (m.home_team_id = ? OR m.away_team_id = ?)
+				) OR EXISTS (
+					SELECT 1 FROM tournament_group_teams tgt
+					JOIN tournament_groups tg ON tg.id = tgt.tournament_group_id AND tg.deleted_at IS NULL
+					JOIN group_stages gs ON gs.id = tg.group_stage_id AND gs.deleted_at IS NULL
+					WHERE gs.season_id = s.id AND tgt.team_id = ?
+				)
+			)
+		)`, true, teamID, teamID, teamID).
+		Find(&list).Error
+
+	return list, err
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type StandingRuleRepository interface {
+	GetByCompetition(competitionID uint) (*models.StandingRule, error)
+	Save(rule *models.StandingRule) error
+}
+
+type standingRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewStandingRuleRepository(db *gorm.DB) StandingRuleRepository {
+	return &standingRuleRepository{db}
+}
+
+func (r *standingRuleRepository) GetByCompetition(competitionID uint) (*models.StandingRule, error) {
+	var rule models.StandingRule
+	if err := r.db.Where("competition_id = ?", competitionID).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *standingRuleRepository) Save(rule *models.StandingRule) error {
+	return r.db.Save(rule).Error
+}
@@ -31,7 +31,7 @@ func (r *teamRepository) GetAll(q utils.QueryParams) ([]models.Team, int64, erro
 	var teams []models.Team
 	var total int64
 
-	db := r.db.Model(&models.Team{})
+	db := r.db.Model(&models.Team{}).Preload("HomeVenue")
 
 	db = utils.ApplyFilters(db, q)
 
@@ -52,7 +52,7 @@ func (r *teamRepository) GetAll(q utils.QueryParams) ([]models.Team, int64, erro
 func (r *teamRepository) GetByID(id uint) (*models.Team, error) {
 	var team models.Team
 
-	if err := r.db.First(&team, id).Error; err != nil {
+	if err := r.db.Preload("HomeVenue").First(&team, id).Error; err != nil {
 		return nil, err
 	}
 
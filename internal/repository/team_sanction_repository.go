@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type TeamSanctionRepository interface {
+	Create(s *models.TeamSanction) error
+	GetByID(id uint) (*models.TeamSanction, error)
+	GetBySeason(seasonID uint) ([]models.TeamSanction, error)
+	Delete(id uint) error
+}
+
+type teamSanctionRepository struct {
+	db *gorm.DB
+}
+
+func NewTeamSanctionRepository(db *gorm.DB) TeamSanctionRepository {
+	return &teamSanctionRepository{db}
+}
+
+func (r *teamSanctionRepository) Create(s *models.TeamSanction) error {
+	return r.db.Omit("Team").Create(s).Error
+}
+
+func (r *teamSanctionRepository) GetByID(id uint) (*models.TeamSanction, error) {
+	var s models.TeamSanction
+	if err := r.db.Preload("Team").First(&s, id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *teamSanctionRepository) GetBySeason(seasonID uint) ([]models.TeamSanction, error) {
+	var list []models.TeamSanction
+
+	err := r.db.
+		Preload("Team").
+		Where("season_id = ?", seasonID).
+		Order("created_at ASC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *teamSanctionRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TeamSanction{}, id).Error
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TransferWindowRepository interface {
+	Create(w *models.TransferWindow) error
+	GetByID(id uint) (*models.TransferWindow, error)
+	GetBySeason(seasonID uint) ([]models.TransferWindow, error)
+	Delete(id uint) error
+	HasOverlap(seasonID uint, opensAt, closesAt time.Time) (bool, error)
+	CountForTeam(teamID uint) (int64, error)
+	FindOpenForTeam(teamID uint, at time.Time) (*models.TransferWindow, error)
+}
+
+const teamCurrentSeasons = `transfer_windows.season_id IN (
+	SELECT s.id FROM seasons s
+	WHERE s.is_current = ? AND s.deleted_at IS NULL AND (
+		EXISTS (
+			SELECT 1 FROM matches m
+			WHERE m.season_id = s.id AND m.deleted_at IS NULL
+				AND (m.home_team_id = ? OR m.away_team_id = ?)
+		) OR EXISTS (
+			SELECT 1 FROM tournament_group_teams tgt
+			JOIN tournament_groups tg ON tg.id = tgt.tournament_group_id AND tg.deleted_at IS NULL
+			JOIN group_stages gs ON gs.id = tg.group_stage_id AND gs.deleted_at IS NULL
+			WHERE gs.season_id = s.id AND tgt.team_id = ?
+		)
+	)
+)`
+
+type transferWindowRepository struct {
+	db *gorm.DB
+}
+
+func NewTransferWindowRepository(db *gorm.DB) TransferWindowRepository {
+	return &transferWindowRepository{db}
+}
+
+func (r *transferWindowRepository) Create(w *models.TransferWindow) error {
+	return r.db.Omit("Season").Create(w).Error
+}
+
+func (r *transferWindowRepository) GetByID(id uint) (*models.TransferWindow, error) {
+	var w models.TransferWindow
+	if err := r.db.First(&w, id).Error; err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *transferWindowRepository) GetBySeason(seasonID uint) ([]models.TransferWindow, error) {
+	var list []models.TransferWindow
+
+	err := r.db.
+		Where("season_id = ?", seasonID).
+		Order("opens_at ASC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *transferWindowRepository) Delete(id uint) error {
+	return r.db.Delete(&models.TransferWindow{}, id).Error
+}
+
+func (r *transferWindowRepository) HasOverlap(seasonID uint, opensAt, closesAt time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.TransferWindow{}).
+		Where("season_id = ? AND opens_at <= ? AND closes_at >= ?", seasonID, closesAt, opensAt).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *transferWindowRepository) CountForTeam(teamID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.TransferWindow{}).
+		Where(teamCurrentSeasons, true, teamID, teamID, teamID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *transferWindowRepository) FindOpenForTeam(teamID uint, at time.Time) (*models.TransferWindow, error) {
+	var w models.TransferWindow
+
+	err := r.db.
+		Where(teamCurrentSeasons, true, teamID, teamID, teamID).
+		Where("transfer_windows.opens_at <= ? AND transfer_windows.closes_at >= ?", at, at).
+		Order("transfer_windows.closes_at ASC").
+		First(&w).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TwoFactorChallengeRepository interface {
+	Create(c *models.TwoFactorChallenge) error
+	Get(tokenHash string) (*models.TwoFactorChallenge, error)
+	ClaimAttempt(id uint, maxAttempts int) (bool, error)
+	MarkUsed(id uint, at time.Time) (bool, error)
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+type twoFactorChallengeRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorChallengeRepository(db *gorm.DB) TwoFactorChallengeRepository {
+	return &twoFactorChallengeRepository{db}
+}
+
+func (r *twoFactorChallengeRepository) Create(c *models.TwoFactorChallenge) error {
+	return r.db.Omit("User").Create(c).Error
+}
+
+func (r *twoFactorChallengeRepository) Get(tokenHash string) (*models.TwoFactorChallenge, error) {
+	var c models.TwoFactorChallenge
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&c).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *twoFactorChallengeRepository) ClaimAttempt(id uint, maxAttempts int) (bool, error) {
+	result := r.db.Model(&models.TwoFactorChallenge{}).
+		Where("id = ? AND used_at IS NULL AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *twoFactorChallengeRepository) MarkUsed(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.TwoFactorChallenge{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *twoFactorChallengeRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.TwoFactorChallenge{})
+	return result.RowsAffected, result.Error
+}
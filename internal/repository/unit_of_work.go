@@ -0,0 +1,73 @@
+package repository
+
+import "gorm.io/gorm"
+
+type Repositories struct {
+	Matches         MatchRepository
+	Goals           GoalRepository
+	MatchEvents     MatchEventRepository
+	EventRevisions  MatchEventRevisionRepository
+	MatchLineups    MatchLineupRepository
+	Players         PlayerRepository
+	PlayerTransfers PlayerTransferRepository
+	PlayerLoans     PlayerLoanRepository
+	PlayerContracts PlayerContractRepository
+	SquadRules      SquadRuleRepository
+	Suspensions     PlayerSuspensionRepository
+	DisciplineRules DisciplineRuleRepository
+	Teams           TeamRepository
+	Seasons         SeasonRepository
+	Brackets        BracketRepository
+	GroupStages     GroupStageRepository
+	PenaltyKicks    PenaltyKickRepository
+	MatchOfficials  MatchOfficialRepository
+	Users           UserRepository
+	RecoveryCodes   RecoveryCodeRepository
+	RefreshTokens   RefreshTokenRepository
+	UserSessions    UserSessionRepository
+}
+
+type UnitOfWork interface {
+	Do(fn func(r Repositories) error) error
+}
+
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db}
+}
+
+func newRepositories(db *gorm.DB) Repositories {
+	return Repositories{
+		Matches:         NewMatchRepository(db),
+		Goals:           NewGoalRepository(db),
+		MatchEvents:     NewMatchEventRepository(db),
+		EventRevisions:  NewMatchEventRevisionRepository(db),
+		MatchLineups:    NewMatchLineupRepository(db),
+		Players:         NewPlayerRepository(db),
+		PlayerTransfers: NewPlayerTransferRepository(db),
+		PlayerLoans:     NewPlayerLoanRepository(db),
+		PlayerContracts: NewPlayerContractRepository(db),
+		SquadRules:      NewSquadRuleRepository(db),
+		Suspensions:     NewPlayerSuspensionRepository(db),
+		DisciplineRules: NewDisciplineRuleRepository(db),
+		Teams:           NewTeamRepository(db),
+		Seasons:         NewSeasonRepository(db),
+		Brackets:        NewBracketRepository(db),
+		GroupStages:     NewGroupStageRepository(db),
+		PenaltyKicks:    NewPenaltyKickRepository(db),
+		MatchOfficials:  NewMatchOfficialRepository(db),
+		Users:           NewUserRepository(db),
+		RecoveryCodes:   NewRecoveryCodeRepository(db),
+		RefreshTokens:   NewRefreshTokenRepository(db),
+		UserSessions:    NewUserSessionRepository(db),
+	}
+}
+
+func (u *unitOfWork) Do(fn func(r Repositories) error) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		return fn(newRepositories(tx))
+	})
+}
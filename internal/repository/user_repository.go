@@ -13,6 +13,7 @@ type UserRepository interface {
 	GetAll() ([]models.User, error)
 	Delete(id uint) error
 	Update(user *models.User) error
+	AdvanceTOTPStep(id uint, step int64) (bool, error)
 }
 
 type userRepository struct {
@@ -58,3 +59,10 @@ func (r *userRepository) Delete(id uint) error {
 func (r *userRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
+
+func (r *userRepository) AdvanceTOTPStep(id uint, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type UserSessionRepository interface {
+	Create(s *models.UserSession) error
+	Update(s *models.UserSession) error
+	GetByID(id uint) (*models.UserSession, error)
+	GetActiveByUser(userID uint, at time.Time) ([]models.UserSession, error)
+	Revoke(id uint, at time.Time) error
+	RevokeByUserExcept(userID, keepID uint, at time.Time) (int64, error)
+}
+
+type userSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewUserSessionRepository(db *gorm.DB) UserSessionRepository {
+	return &userSessionRepository{db}
+}
+
+func (r *userSessionRepository) Create(s *models.UserSession) error {
+	return r.db.Omit("User").Create(s).Error
+}
+
+func (r *userSessionRepository) Update(s *models.UserSession) error {
+	return r.db.Omit("User").Save(s).Error
+}
+
+func (r *userSessionRepository) GetByID(id uint) (*models.UserSession, error) {
+	var s models.UserSession
+	if err := r.db.First(&s, id).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *userSessionRepository) GetActiveByUser(userID uint, at time.Time) ([]models.UserSession, error) {
+	var list []models.UserSession
+
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, at).
+		Order("last_used_at DESC").
+		Find(&list).Error
+
+	return list, err
+}
+
+func (r *userSessionRepository) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&models.UserSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *userSessionRepository) RevokeByUserExcept(userID, keepID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&models.UserSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepID).
+		Update("revoked_at", at)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"football-backend/internal/models"
+	"football-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type VenueRepository interface {
+	Create(v *models.Venue) error
+	GetAll(q utils.QueryParams) ([]models.Venue, int64, error)
+	GetByID(id uint) (*models.Venue, error)
+	Update(v *models.Venue) error
+	Delete(id uint) error
+	CountHomeTeams(venueID uint) (int64, error)
+	CountUpcomingMatches(venueID uint) (int64, error)
+}
+
+type venueRepository struct {
+	db *gorm.DB
+}
+
+func NewVenueRepository(db *gorm.DB) VenueRepository {
+	return &venueRepository{db}
+}
+
+func (r *venueRepository) Create(v *models.Venue) error {
+	return r.db.Create(v).Error
+}
+
+func (r *venueRepository) GetAll(q utils.QueryParams) ([]models.Venue, int64, error) {
+	var venues []models.Venue
+	var total int64
+
+	db := r.db.Model(&models.Venue{})
+
+	db = utils.ApplyFilters(db, q)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db = db.Order(q.Sort + " " + q.Order)
+
+	offset := (q.Page - 1) * q.Limit
+	if err := db.Offset(offset).Limit(q.Limit).Find(&venues).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return venues, total, nil
+}
+
+func (r *venueRepository) GetByID(id uint) (*models.Venue, error) {
+	var v models.Venue
+
+	if err := r.db.First(&v, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+func (r *venueRepository) Update(v *models.Venue) error {
+	return r.db.Save(v).Error
+}
+
+func (r *venueRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Venue{}, id).Error
+}
+
+func (r *venueRepository) CountHomeTeams(venueID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Team{}).
+		Where("home_venue_id = ?", venueID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *venueRepository) CountUpcomingMatches(venueID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Match{}).
+		Where("venue_id = ?", venueID).
+		Where("status IN ?", []string{"DIJADWALKAN", "SEDANG BERLANGSUNG", "ISTIRAHAT", "DITUNDA"}).
+		Count(&count).Error
+	return count, err
+}
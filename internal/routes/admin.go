@@ -11,8 +11,14 @@ func RegisterAdminRoutes(
 	user *handler.UserHandler,
 	team *handler.TeamHandler,
 	player *handler.PlayerHandler,
+	competition *handler.CompetitionHandler,
+	official *handler.OfficialHandler,
+	venue *handler.VenueHandler,
 ) {
 	UserAdminRoutes(r, user)
 	TeamAdminRoutes(r, team)
 	PlayerAdminRoutes(r, player)
+	CompetitionAdminRoutes(r, competition)
+	OfficialAdminRoutes(r, official)
+	VenueAdminRoutes(r, venue)
 }
@@ -2,20 +2,34 @@ package routes
 
 import (
 	"football-backend/internal/handler"
+	"football-backend/internal/jwtkeys"
 	"football-backend/internal/middleware"
 	"football-backend/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
 
-func AuthRoutes(r *gin.RouterGroup, h *handler.AuthHandler, userRepo repository.UserRepository) {
+func AuthRoutes(r *gin.RouterGroup, h *handler.AuthHandler, userRepo repository.UserRepository, sessionRepo repository.UserSessionRepository, keys *jwtkeys.KeySet) {
 	auth := r.Group("/auth")
 	auth.POST("/register", h.Register)
 	auth.POST("/login", h.Login)
+	auth.POST("/login/2fa", h.LoginTwoFactor)
 	auth.POST("/refresh", h.Refresh)
 	auth.POST("/logout", h.Logout)
+	auth.POST("/password/forgot", h.ForgotPassword)
+	auth.POST("/password/reset", h.ResetPassword)
 
 	protected := r.Group("/")
-	protected.Use(middleware.JWTAuth(userRepo))
+	protected.Use(middleware.JWTAuth(userRepo, sessionRepo, keys))
 	protected.GET("/me", h.Me)
+	protected.GET("/auth/sessions", h.Sessions)
+	protected.DELETE("/auth/sessions", h.RevokeOtherSessions)
+	protected.DELETE("/auth/sessions/:id", h.RevokeSession)
+	protected.POST("/auth/live-ticket", h.LiveTicket)
+	protected.POST("/auth/password/change", h.ChangePassword)
+	protected.GET("/auth/2fa", h.TwoFactorStatus)
+	protected.POST("/auth/2fa/enroll", h.EnrollTwoFactor)
+	protected.POST("/auth/2fa/confirm", h.ConfirmTwoFactor)
+	protected.POST("/auth/2fa/recovery-codes", h.RegenerateRecoveryCodes)
+	protected.DELETE("/auth/2fa", h.DisableTwoFactor)
 }
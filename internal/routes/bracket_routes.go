@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func BracketViewerRoutes(r *gin.RouterGroup, h *handler.BracketHandler) {
+	r.GET("/brackets", h.GetBySeason)
+	r.GET("/brackets/:id", h.GetByID)
+}
+
+func BracketStaffRoutes(r *gin.RouterGroup, h *handler.BracketHandler) {
+	r.POST("/brackets", h.Create)
+	r.POST("/matches/:id/penalties", h.RecordPenalties)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func CompetitionViewerRoutes(r *gin.RouterGroup, h *handler.CompetitionHandler) {
+	r.GET("/competitions", h.GetAll)
+	r.GET("/competitions/:id", h.GetByID)
+	r.GET("/competitions/:id/seasons", h.GetSeasons)
+	r.GET("/competitions/:id/seasons/:season_id", h.GetSeason)
+	r.GET("/competitions/:id/seasons/:season_id/standing", h.SeasonStanding)
+	r.GET("/competitions/:id/seasons/:season_id/standing/history", h.SeasonPositionHistory)
+	r.GET("/competitions/:id/seasons/:season_id/top-scorers", h.SeasonTopScorers)
+	r.GET("/competitions/:id/seasons/:season_id/sanctions", h.GetSanctions)
+	r.GET("/competitions/:id/seasons/:season_id/transfer-windows", h.GetTransferWindows)
+	r.GET("/competitions/:id/standing-rule", h.GetStandingRule)
+	r.GET("/competitions/:id/squad-rule", h.GetSquadRule)
+	r.GET("/competitions/:id/discipline-rule", h.GetDisciplineRule)
+}
+
+func CompetitionAdminRoutes(r *gin.RouterGroup, h *handler.CompetitionHandler) {
+	r.POST("/competitions", h.Create)
+	r.PUT("/competitions/:id", h.Update)
+	r.DELETE("/competitions/:id", h.Delete)
+	r.POST("/competitions/:id/seasons", h.CreateSeason)
+	r.PUT("/competitions/:id/seasons/:season_id", h.UpdateSeason)
+	r.DELETE("/competitions/:id/seasons/:season_id", h.DeleteSeason)
+	r.POST("/competitions/:id/seasons/:season_id/sanctions", h.AddSanction)
+	r.DELETE("/competitions/:id/seasons/:season_id/sanctions/:sanction_id", h.DeleteSanction)
+	r.POST("/competitions/:id/seasons/:season_id/transfer-windows", h.AddTransferWindow)
+	r.DELETE("/competitions/:id/seasons/:season_id/transfer-windows/:window_id", h.DeleteTransferWindow)
+	r.PUT("/competitions/:id/standing-rule", h.UpdateStandingRule)
+	r.PUT("/competitions/:id/squad-rule", h.UpdateSquadRule)
+	r.PUT("/competitions/:id/discipline-rule", h.UpdateDisciplineRule)
+}
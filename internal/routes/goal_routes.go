@@ -13,4 +13,6 @@ func GoalViewerRoutes(r *gin.RouterGroup, h *handler.GoalHandler) {
 
 func GoalStaffRoutes(r *gin.RouterGroup, h *handler.GoalHandler) {
 	r.POST("/goals", h.AddGoal)
+	r.PUT("/goals/:id", h.UpdateGoal)
+	r.DELETE("/goals/:id", h.DeleteGoal)
 }
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func GroupStageViewerRoutes(r *gin.RouterGroup, h *handler.GroupStageHandler) {
+	r.GET("/group-stages", h.GetBySeason)
+	r.GET("/group-stages/:id", h.GetByID)
+	r.GET("/group-stages/:id/standing", h.Standing)
+}
+
+func GroupStageStaffRoutes(r *gin.RouterGroup, h *handler.GroupStageHandler) {
+	r.POST("/group-stages", h.Create)
+	r.POST("/group-stages/:id/draw", h.DrawKnockout)
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func LiveViewerRoutes(r *gin.RouterGroup, h *handler.LiveHandler) {
+	r.GET("/live/sse", h.AllSSE)
+	r.GET("/live/ws", h.AllWS)
+	r.GET("/live/matches/:id/sse", h.MatchSSE)
+	r.GET("/live/matches/:id/ws", h.MatchWS)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MatchEventViewerRoutes(r *gin.RouterGroup, h *handler.MatchEventHandler) {
+	r.GET("/matches/:id/timeline", h.Timeline)
+}
+
+func MatchEventStaffRoutes(r *gin.RouterGroup, h *handler.MatchEventHandler) {
+	r.POST("/matches/:id/events", h.Record)
+	r.PUT("/matches/:id/events/:event_id", h.Update)
+	r.DELETE("/matches/:id/events/:event_id", h.Delete)
+	r.GET("/matches/:id/events/revisions", h.Revisions)
+}
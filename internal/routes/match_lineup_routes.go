@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MatchLineupViewerRoutes(r *gin.RouterGroup, h *handler.MatchLineupHandler) {
+	r.GET("/matches/:id/lineups", h.GetByMatch)
+}
+
+func MatchLineupStaffRoutes(r *gin.RouterGroup, h *handler.MatchLineupHandler) {
+	r.PUT("/matches/:id/lineups", h.Submit)
+}
@@ -11,10 +11,20 @@ func MatchViewerRoutes(r *gin.RouterGroup, h *handler.MatchHandler) {
 	r.GET("/matches/:id", h.GetByID)
 	r.GET("/matches/:id/report", h.Report)
 	r.GET("/matches/standing", h.Standing)
+	r.GET("/matches/standing/history", h.PositionHistory)
 }
 
 func MatchStaffRoutes(r *gin.RouterGroup, h *handler.MatchHandler) {
 	r.POST("/matches", h.Create)
+	r.POST("/matches/fixtures", h.GenerateFixtures)
 	r.PUT("/matches/:id", h.Update)
 	r.POST("/matches/:id/result", h.SubmitResult)
+	r.POST("/matches/:id/start", h.Start)
+	r.POST("/matches/:id/half-time", h.HalfTime)
+	r.POST("/matches/:id/resume", h.Resume)
+	r.POST("/matches/:id/finish", h.Finish)
+	r.POST("/matches/:id/postpone", h.Postpone)
+	r.POST("/matches/:id/abandon", h.Abandon)
+	r.POST("/matches/:id/cancel", h.Cancel)
+	r.POST("/matches/:id/reschedule", h.Reschedule)
 }
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func OfficialViewerRoutes(r *gin.RouterGroup, h *handler.OfficialHandler) {
+	r.GET("/officials", h.GetAll)
+	r.GET("/officials/:id", h.GetByID)
+	r.GET("/officials/:id/stats", h.Stats)
+	r.GET("/matches/:id/officials", h.MatchOfficials)
+}
+
+func OfficialStaffRoutes(r *gin.RouterGroup, h *handler.OfficialHandler) {
+	r.PUT("/matches/:id/officials", h.AssignMatch)
+}
+
+func OfficialAdminRoutes(r *gin.RouterGroup, h *handler.OfficialHandler) {
+	r.POST("/officials", h.Create)
+	r.PUT("/officials/:id", h.Update)
+	r.DELETE("/officials/:id", h.Delete)
+}
@@ -10,14 +10,29 @@ func PlayerViewerRoutes(r *gin.RouterGroup, h *handler.PlayerHandler) {
 	r.GET("/players", h.GetAll)
 	r.GET("/players/:id", h.GetByID)
 	r.GET("/players/by-team/:team_id", h.GetByTeam)
+	r.GET("/players/leaderboard", h.Leaderboard)
+	r.GET("/players/:id/stats", h.Stats)
+	r.GET("/players/:id/transfers", h.Transfers)
+	r.GET("/players/:id/loans", h.Loans)
+	r.GET("/players/:id/contracts", h.Contracts)
+	r.GET("/players/contracts/expiring", h.ExpiringContracts)
+	r.GET("/players/:id/injuries", h.Injuries)
+	r.GET("/players/:id/suspensions", h.Suspensions)
 }
 
 func PlayerStaffRoutes(r *gin.RouterGroup, h *handler.PlayerHandler) {
 	r.POST("/players", h.Create)
 	r.PUT("/players/:id", h.Update)
 	r.POST("/players/:id/transfer", h.Transfer)
+	r.POST("/players/:id/loans", h.Loan)
+	r.POST("/players/:id/loans/:loan_id/exercise-option", h.ExerciseLoanOption)
+	r.PUT("/players/:id/contract", h.UpdateContract)
+	r.POST("/players/:id/injuries", h.AddInjury)
+	r.PUT("/players/:id/injuries/:injury_id", h.UpdateInjury)
+	r.POST("/players/:id/suspensions", h.AddSuspension)
 }
 
 func PlayerAdminRoutes(r *gin.RouterGroup, h *handler.PlayerHandler) {
 	r.DELETE("/players/:id", h.Delete)
+	r.DELETE("/players/:id/suspensions/:suspension_id", h.DeleteSuspension)
 }
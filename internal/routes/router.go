@@ -2,6 +2,7 @@ package routes
 
 import (
 	"football-backend/internal/handler"
+	"football-backend/internal/jwtkeys"
 	"football-backend/internal/middleware"
 	"football-backend/internal/repository"
 
@@ -16,24 +17,44 @@ func RegisterAll(
 	player *handler.PlayerHandler,
 	match *handler.MatchHandler,
 	goal *handler.GoalHandler,
+	competition *handler.CompetitionHandler,
+	bracket *handler.BracketHandler,
+	groupStage *handler.GroupStageHandler,
+	matchEvent *handler.MatchEventHandler,
+	lineup *handler.MatchLineupHandler,
+	live *handler.LiveHandler,
+	official *handler.OfficialHandler,
+	venue *handler.VenueHandler,
 	userRepo repository.UserRepository,
+	sessionRepo repository.UserSessionRepository,
+	keys *jwtkeys.KeySet,
+	totpRequiredRoles []string,
 ) {
+	r.GET("/.well-known/jwks.json", auth.JWKS)
+
 	api := r.Group("/api/v1")
 
-	AuthRoutes(api, auth, userRepo)
+	AuthRoutes(api, auth, userRepo, sessionRepo, keys)
 
 	secured := api.Group("/")
-	secured.Use(middleware.JWTAuth(userRepo))
+	secured.Use(middleware.JWTAuth(userRepo, sessionRepo, keys))
+	secured.Use(middleware.RequireTwoFactor(totpRequiredRoles))
 
 	viewer := secured.Group("/")
 	viewer.Use(middleware.RequireRoles("ADMIN", "STAFF", "VIEWER"))
-	RegisterViewerRoutes(viewer, user, team, player, match, goal)
+	RegisterViewerRoutes(viewer, user, team, player, match, goal, competition, bracket, groupStage, matchEvent, lineup, official, venue)
+
+	stream := api.Group("/")
+	stream.Use(middleware.LiveTicketAuth(userRepo, sessionRepo, keys))
+	stream.Use(middleware.RequireTwoFactor(totpRequiredRoles))
+	stream.Use(middleware.RequireRoles("ADMIN", "STAFF", "VIEWER"))
+	LiveViewerRoutes(stream, live)
 
 	staff := secured.Group("/")
 	staff.Use(middleware.RequireRoles("ADMIN", "STAFF"))
-	RegisterStaffRoutes(staff, player, match, goal)
+	RegisterStaffRoutes(staff, player, match, goal, bracket, groupStage, matchEvent, lineup, official)
 
 	admin := secured.Group("/")
 	admin.Use(middleware.RequireRoles("ADMIN"))
-	RegisterAdminRoutes(admin, user, team, player)
+	RegisterAdminRoutes(admin, user, team, player, competition, official, venue)
 }
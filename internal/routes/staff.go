@@ -11,8 +11,18 @@ func RegisterStaffRoutes(
 	player *handler.PlayerHandler,
 	match *handler.MatchHandler,
 	goal *handler.GoalHandler,
+	bracket *handler.BracketHandler,
+	groupStage *handler.GroupStageHandler,
+	matchEvent *handler.MatchEventHandler,
+	lineup *handler.MatchLineupHandler,
+	official *handler.OfficialHandler,
 ) {
 	PlayerStaffRoutes(r, player)
 	MatchStaffRoutes(r, match)
 	GoalStaffRoutes(r, goal)
+	BracketStaffRoutes(r, bracket)
+	GroupStageStaffRoutes(r, groupStage)
+	MatchEventStaffRoutes(r, matchEvent)
+	MatchLineupStaffRoutes(r, lineup)
+	OfficialStaffRoutes(r, official)
 }
@@ -9,6 +9,10 @@ import (
 func TeamViewerRoutes(r *gin.RouterGroup, h *handler.TeamHandler) {
 	r.GET("/teams", h.GetAll)
 	r.GET("/teams/:id", h.GetByID)
+	r.GET("/teams/:id/stats", h.Stats)
+	r.GET("/teams/:id/transfers", h.Transfers)
+	r.GET("/teams/:id/head-to-head/:opponent_id", h.HeadToHead)
+	r.GET("/teams/:id/unavailable", h.Unavailable)
 }
 
 func TeamAdminRoutes(r *gin.RouterGroup, h *handler.TeamHandler) {
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"football-backend/internal/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func VenueViewerRoutes(r *gin.RouterGroup, h *handler.VenueHandler) {
+	r.GET("/venues", h.GetAll)
+	r.GET("/venues/:id", h.GetByID)
+}
+
+func VenueAdminRoutes(r *gin.RouterGroup, h *handler.VenueHandler) {
+	r.POST("/venues", h.Create)
+	r.PUT("/venues/:id", h.Update)
+	r.DELETE("/venues/:id", h.Delete)
+}
@@ -13,10 +13,24 @@ func RegisterViewerRoutes(
 	player *handler.PlayerHandler,
 	match *handler.MatchHandler,
 	goal *handler.GoalHandler,
+	competition *handler.CompetitionHandler,
+	bracket *handler.BracketHandler,
+	groupStage *handler.GroupStageHandler,
+	matchEvent *handler.MatchEventHandler,
+	lineup *handler.MatchLineupHandler,
+	official *handler.OfficialHandler,
+	venue *handler.VenueHandler,
 ) {
 	UserViewerRoutes(r, user)
 	TeamViewerRoutes(r, team)
 	PlayerViewerRoutes(r, player)
 	MatchViewerRoutes(r, match)
 	GoalViewerRoutes(r, goal)
+	CompetitionViewerRoutes(r, competition)
+	BracketViewerRoutes(r, bracket)
+	GroupStageViewerRoutes(r, groupStage)
+	MatchEventViewerRoutes(r, matchEvent)
+	MatchLineupViewerRoutes(r, lineup)
+	OfficialViewerRoutes(r, official)
+	VenueViewerRoutes(r, venue)
 }
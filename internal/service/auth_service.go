@@ -1,12 +1,19 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
 	"strings"
 	"time"
 
-	"football-backend/internal/config"
 	apperror "football-backend/internal/errors"
+	"football-backend/internal/jwtkeys"
 	"football-backend/internal/models"
+	"football-backend/internal/notifier"
 	"football-backend/internal/repository"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -16,19 +23,96 @@ import (
 
 type AuthService interface {
 	Register(username, password, role string) error
-	Login(username, password string) (string, string, *models.User, error)
+	Login(username, password string, client ClientInfo) (*AuthTokens, error)
+	VerifyTwoFactor(challengeToken, code string, client ClientInfo) (*AuthTokens, error)
 	GetProfile(userID uint) (*models.User, error)
-	Refresh(refreshToken string) (string, string, *models.User, error)
+	Refresh(refreshToken string, client ClientInfo) (*AuthTokens, error)
 	Logout(refreshToken string) error
+	Sessions(userID uint) ([]models.UserSession, error)
+	RevokeSession(userID, sessionID uint) error
+	RevokeOtherSessions(userID, currentSessionID uint) (int64, error)
+	LiveTicket(userID, sessionID uint) (string, time.Time, error)
+	ChangePassword(userID, sessionID uint, currentPassword, newPassword string) error
+	RequestPasswordReset(username string, client ClientInfo) error
+	ResetPassword(token, newPassword string) error
+	PurgeExpiredTokens(now time.Time) error
+	JWKS() jwtkeys.JWKS
 }
 
+type ClientInfo struct {
+	DeviceName string
+	IPAddress  string
+	UserAgent  string
+}
+
+type AuthTokens struct {
+	AccessToken  string
+	RefreshToken string
+	SessionID    uint
+	User         *models.User
+
+	ChallengeToken     string
+	ChallengeExpiresAt time.Time
+}
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+	resetTokenTTL   = 30 * time.Minute
+	challengeTTL    = 5 * time.Minute
+	liveTicketTTL   = time.Minute
+
+	maxChallengeAttempts = 5
+
+	minPasswordLength = 6
+
+	maxDeviceNameLength = 255
+	maxUserAgentLength  = 512
+)
+
 type authService struct {
-	repo   repository.UserRepository
-	rtRepo repository.RefreshTokenRepository
+	repo          repository.UserRepository
+	rtRepo        repository.RefreshTokenRepository
+	sessionRepo   repository.UserSessionRepository
+	resetRepo     repository.PasswordResetRepository
+	recoveryRepo  repository.RecoveryCodeRepository
+	challengeRepo repository.TwoFactorChallengeRepository
+	notifier      notifier.Notifier
+	keys          *jwtkeys.KeySet
+	uow           repository.UnitOfWork
 }
 
-func NewAuthService(userRepo repository.UserRepository, rtRepo repository.RefreshTokenRepository) AuthService {
-	return &authService{repo: userRepo, rtRepo: rtRepo}
+func NewAuthService(
+	userRepo repository.UserRepository,
+	rtRepo repository.RefreshTokenRepository,
+	sessionRepo repository.UserSessionRepository,
+	resetRepo repository.PasswordResetRepository,
+	recoveryRepo repository.RecoveryCodeRepository,
+	challengeRepo repository.TwoFactorChallengeRepository,
+	n notifier.Notifier,
+	keys *jwtkeys.KeySet,
+	uow repository.UnitOfWork,
+) AuthService {
+	return &authService{
+		repo:          userRepo,
+		rtRepo:        rtRepo,
+		sessionRepo:   sessionRepo,
+		resetRepo:     resetRepo,
+		recoveryRepo:  recoveryRepo,
+		challengeRepo: challengeRepo,
+		notifier:      n,
+		keys:          keys,
+		uow:           uow,
+	}
+}
+
+func authServiceTx(r repository.Repositories, keys *jwtkeys.KeySet) *authService {
+	return &authService{
+		repo:        r.Users,
+		rtRepo:      r.RefreshTokens,
+		sessionRepo: r.UserSessions,
+		keys:        keys,
+	}
 }
 
 func (s *authService) Register(username, password, role string) error {
@@ -66,27 +150,150 @@ func (s *authService) Register(username, password, role string) error {
 	return nil
 }
 
-func (s *authService) Login(username, password string) (string, string, *models.User, error) {
+func (s *authService) Login(username, password string, client ClientInfo) (*AuthTokens, error) {
 	user, err := s.repo.FindByUsername(username)
 	if err != nil {
-		return "", "", nil, apperror.NewNotFoundError("user tidak ditemukan")
+		return nil, apperror.NewNotFoundError("user tidak ditemukan")
 	}
 
 	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
-		return "", "", nil, apperror.NewValidationError("password salah")
+		return nil, apperror.NewValidationError("password salah")
+	}
+
+	if user.TOTPEnabled {
+		return s.startChallenge(user, client)
+	}
+	return s.startSession(user, client)
+}
+
+func (s *authService) startSession(user *models.User, client ClientInfo) (*AuthTokens, error) {
+	now := time.Now()
+	session := &models.UserSession{UserID: user.ID, ExpiresAt: now.Add(refreshTokenTTL)}
+	applyClientInfo(session, client, now)
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, apperror.NewInternalError("gagal membuat sesi")
+	}
+
+	return s.issueTokens(user, session, "")
+}
+
+func (s *authService) startChallenge(user *models.User, client ClientInfo) (*AuthTokens, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal membuat challenge 2FA")
+	}
+
+	challenge := &models.TwoFactorChallenge{
+		UserID:     user.ID,
+		TokenHash:  hashToken(token),
+		DeviceName: truncate(client.DeviceName, maxDeviceNameLength),
+		ExpiresAt:  time.Now().Add(challengeTTL),
+	}
+	if err := s.challengeRepo.Create(challenge); err != nil {
+		return nil, apperror.NewInternalError("gagal membuat challenge 2FA")
+	}
+
+	return &AuthTokens{User: user, ChallengeToken: token, ChallengeExpiresAt: challenge.ExpiresAt}, nil
+}
+
+func (s *authService) VerifyTwoFactor(challengeToken, code string, client ClientInfo) (*AuthTokens, error) {
+	challenge, err := s.challengeRepo.Get(hashToken(challengeToken))
+	if err != nil || challenge.UsedAt != nil || time.Now().After(challenge.ExpiresAt) {
+		return nil, apperror.NewUnauthorizedError("challenge 2FA tidak valid atau sudah kadaluarsa, silakan login ulang")
+	}
+
+	user, err := s.repo.GetByID(challenge.UserID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("user tidak ditemukan")
+	}
+	if !user.TOTPEnabled {
+		return nil, apperror.NewUnauthorizedError("challenge 2FA tidak valid atau sudah kadaluarsa, silakan login ulang")
+	}
+
+	claimed, err := s.challengeRepo.ClaimAttempt(challenge.ID, maxChallengeAttempts)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal memperbarui challenge 2FA")
+	}
+	if !claimed {
+		log.Printf("security: 2fa challenge rejected after %d attempts user=%d ip=%s", maxChallengeAttempts, user.ID, client.IPAddress)
+		return nil, apperror.NewUnauthorizedError("terlalu banyak percobaan kode 2FA, silakan login ulang")
+	}
+
+	ok, err := verifySecondFactor(s.repo, s.recoveryRepo, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apperror.NewValidationError("kode 2FA salah")
 	}
 
-	access, refresh, expiresAt, jti, err := generateTokens(user)
+	used, err := s.challengeRepo.MarkUsed(challenge.ID, time.Now())
 	if err != nil {
-		return "", "", nil, apperror.NewInternalError("gagal membuat token")
+		return nil, apperror.NewInternalError("gagal memperbarui challenge 2FA")
 	}
+	if !used {
+		return nil, apperror.NewUnauthorizedError("challenge 2FA tidak valid atau sudah kadaluarsa, silakan login ulang")
+	}
+
+	if client.DeviceName == "" {
+		client.DeviceName = challenge.DeviceName
+	}
+	return s.startSession(user, client)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
 
-	_ = s.rtRepo.DeleteByUser(user.ID)
-	if err := s.rtRepo.Save(user.ID, refresh, jti, expiresAt); err != nil {
-		return "", "", nil, apperror.NewInternalError("gagal menyimpan refresh token")
+func applyClientInfo(session *models.UserSession, client ClientInfo, now time.Time) {
+	if client.DeviceName != "" {
+		session.DeviceName = truncate(client.DeviceName, maxDeviceNameLength)
 	}
+	session.IPAddress = client.IPAddress
+	session.UserAgent = truncate(client.UserAgent, maxUserAgentLength)
+	session.LastUsedAt = now
+}
 
-	return access, refresh, user, nil
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
+
+func (s *authService) issueTokens(user *models.User, session *models.UserSession, familyID string) (*AuthTokens, error) {
+	access, refresh, expiresAt, jti, err := s.generateTokens(user, session.ID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal membuat token")
+	}
+	if familyID == "" {
+		familyID = jti
+	}
+
+	rt := &models.RefreshToken{
+		UserID:    user.ID,
+		SessionID: session.ID,
+		TokenHash: hashToken(refresh),
+		JTI:       jti,
+		FamilyID:  familyID,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.rtRepo.Save(rt); err != nil {
+		return nil, apperror.NewInternalError("gagal menyimpan refresh token")
+	}
+
+	session.ExpiresAt = expiresAt
+	if err := s.sessionRepo.Update(session); err != nil {
+		return nil, apperror.NewInternalError("gagal memperbarui sesi")
+	}
+
+	return &AuthTokens{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		SessionID:    session.ID,
+		User:         user,
+	}, nil
 }
 
 func (s *authService) GetProfile(id uint) (*models.User, error) {
@@ -97,16 +304,17 @@ func (s *authService) GetProfile(id uint) (*models.User, error) {
 	return u, nil
 }
 
-func generateTokens(user *models.User) (string, string, time.Time, string, error) {
+func (s *authService) generateTokens(user *models.User, sessionID uint) (string, string, time.Time, string, error) {
 	now := time.Now()
-	accessExp := now.Add(15 * time.Minute)
-	refreshExp := now.Add(7 * 24 * time.Hour)
+	accessExp := now.Add(accessTokenTTL)
+	refreshExp := now.Add(refreshTokenTTL)
 	jti := uuid.NewString()
 
 	accessClaims := jwt.MapClaims{
 		"user_id": user.ID,
 		"role":    user.Role,
 		"ver":     user.TokenVersion,
+		"sid":     sessionID,
 		"exp":     accessExp.Unix(),
 		"iat":     now.Unix(),
 	}
@@ -115,20 +323,18 @@ func generateTokens(user *models.User) (string, string, time.Time, string, error
 		"user_id": user.ID,
 		"role":    user.Role,
 		"type":    "refresh",
+		"sid":     sessionID,
 		"exp":     refreshExp.Unix(),
 		"iat":     now.Unix(),
 		"jti":     jti,
 	}
 
-	at := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	rt := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-
-	atSigned, err := at.SignedString([]byte(config.Load().JWTSecret))
+	atSigned, err := s.keys.Sign(accessClaims)
 	if err != nil {
 		return "", "", time.Time{}, "", err
 	}
 
-	rtSigned, err := rt.SignedString([]byte(config.Load().JWTSecret))
+	rtSigned, err := s.keys.Sign(refreshClaims)
 	if err != nil {
 		return "", "", time.Time{}, "", err
 	}
@@ -136,59 +342,307 @@ func generateTokens(user *models.User) (string, string, time.Time, string, error
 	return atSigned, rtSigned, refreshExp, jti, nil
 }
 
-func (s *authService) Refresh(refreshToken string) (string, string, *models.User, error) {
-	rt, err := s.rtRepo.Get(refreshToken)
+func (s *authService) LiveTicket(userID, sessionID uint) (string, time.Time, error) {
+	user, err := s.repo.GetByID(userID)
 	if err != nil {
-		return "", "", nil, apperror.NewUnauthorizedError("refresh token tidak dikenal")
+		return "", time.Time{}, apperror.NewNotFoundError("user tidak ditemukan")
 	}
 
-	if time.Now().After(rt.ExpiresAt) {
-		_ = s.rtRepo.Delete(refreshToken)
-		return "", "", nil, apperror.NewUnauthorizedError("refresh token sudah kadaluarsa")
+	now := time.Now()
+	exp := now.Add(liveTicketTTL)
+	ticket, err := s.keys.Sign(jwt.MapClaims{
+		"user_id": user.ID,
+		"role":    user.Role,
+		"ver":     user.TokenVersion,
+		"sid":     sessionID,
+		"type":    "live",
+		"exp":     exp.Unix(),
+		"iat":     now.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, apperror.NewInternalError("gagal membuat tiket live")
 	}
+	return ticket, exp, nil
+}
 
-	user, err := s.repo.GetByID(rt.UserID)
+func (s *authService) Refresh(refreshToken string, client ClientInfo) (*AuthTokens, error) {
+	hash := hashToken(refreshToken)
+	rt, err := s.rtRepo.Get(hash)
 	if err != nil {
-		return "", "", nil, apperror.NewNotFoundError("user tidak ditemukan")
+		return nil, apperror.NewUnauthorizedError("refresh token tidak dikenal")
 	}
 
-	user.TokenVersion++
-	if err := s.repo.Update(user); err != nil {
-		return "", "", nil, apperror.NewInternalError("gagal update token version")
+	now := time.Now()
+	if rt.UsedAt != nil {
+		s.revokeFamily(rt, client, now)
+		return nil, apperror.NewUnauthorizedError("refresh token sudah pernah dipakai, sesi dicabut demi keamanan")
+	}
+	if rt.RevokedAt != nil {
+		return nil, apperror.NewUnauthorizedError("refresh token sudah dicabut")
+	}
+	if now.After(rt.ExpiresAt) {
+		_ = s.rtRepo.Delete(hash)
+		return nil, apperror.NewUnauthorizedError("refresh token sudah kadaluarsa")
 	}
 
-	access, newRefresh, expiresAt, newJti, err := generateTokens(user)
+	session, err := s.sessionRepo.GetByID(rt.SessionID)
+	if err != nil || session.RevokedAt != nil {
+		_ = s.rtRepo.Delete(hash)
+		return nil, apperror.NewUnauthorizedError("sesi sudah berakhir, silakan login ulang")
+	}
+
+	user, err := s.repo.GetByID(rt.UserID)
 	if err != nil {
-		return "", "", nil, apperror.NewInternalError("gagal membuat token baru")
+		return nil, apperror.NewNotFoundError("user tidak ditemukan")
 	}
 
-	_ = s.rtRepo.Delete(refreshToken)
-	if err := s.rtRepo.Save(user.ID, newRefresh, newJti, expiresAt); err != nil {
-		return "", "", nil, apperror.NewInternalError("gagal menyimpan refresh token baru")
+	var tokens *AuthTokens
+	reused := false
+	err = s.uow.Do(func(r repository.Repositories) error {
+		rotated, err := r.RefreshTokens.MarkUsed(rt.ID, now)
+		if err != nil {
+			return apperror.NewInternalError("gagal memperbarui refresh token lama")
+		}
+		if !rotated {
+			reused = true
+			return apperror.NewUnauthorizedError("refresh token sudah pernah dipakai, sesi dicabut demi keamanan")
+		}
+
+		applyClientInfo(session, client, now)
+		tokens, err = authServiceTx(r, s.keys).issueTokens(user, session, rt.FamilyID)
+		return err
+	})
+	if reused {
+		s.revokeFamily(rt, client, now)
+	}
+	if err != nil {
+		return nil, err
 	}
+	return tokens, nil
+}
+
+func (s *authService) revokeFamily(rt *models.RefreshToken, client ClientInfo, now time.Time) {
+	log.Printf(
+		"security: refresh token reuse detected user=%d session=%d family=%s jti=%s ip=%s user_agent=%q",
+		rt.UserID, rt.SessionID, rt.FamilyID, rt.JTI, client.IPAddress, client.UserAgent,
+	)
 
-	return access, newRefresh, user, nil
+	if err := s.rtRepo.RevokeFamily(rt.FamilyID, now); err != nil {
+		log.Printf("security: failed to revoke token family %s: %v", rt.FamilyID, err)
+	}
+	if err := s.sessionRepo.Revoke(rt.SessionID, now); err != nil {
+		log.Printf("security: failed to revoke session %d: %v", rt.SessionID, err)
+	}
 }
 
 func (s *authService) Logout(refreshToken string) error {
-	rt, err := s.rtRepo.Get(refreshToken)
-	if err != nil {
+	rt, err := s.rtRepo.Get(hashToken(refreshToken))
+	if err != nil || rt.UsedAt != nil || rt.RevokedAt != nil {
 		return apperror.NewUnauthorizedError("refresh token tidak valid")
 	}
 
-	user, err := s.repo.GetByID(rt.UserID)
+	return s.endSession(rt.SessionID)
+}
+
+func (s *authService) ChangePassword(userID, sessionID uint, currentPassword, newPassword string) error {
+	user, err := s.repo.GetByID(userID)
 	if err != nil {
 		return apperror.NewNotFoundError("user tidak ditemukan")
 	}
 
-	user.TokenVersion++
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)) != nil {
+		return apperror.NewValidationError("password lama salah")
+	}
+	if currentPassword == newPassword {
+		return apperror.NewValidationError("password baru harus berbeda dari password lama")
+	}
+	if err := s.setPassword(user, newPassword); err != nil {
+		return err
+	}
+
+	_, err = s.RevokeOtherSessions(userID, sessionID)
+	return err
+}
+
+func (s *authService) RequestPasswordReset(username string, client ClientInfo) error {
+	user, err := s.repo.FindByUsername(username)
+	if err != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := s.resetRepo.InvalidateByUser(user.ID, now); err != nil {
+		return apperror.NewInternalError("gagal membuat token reset")
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return apperror.NewInternalError("gagal membuat token reset")
+	}
+
+	reset := &models.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hashToken(token),
+		RequestIP: client.IPAddress,
+		ExpiresAt: now.Add(resetTokenTTL),
+	}
+	if err := s.resetRepo.Create(reset); err != nil {
+		return apperror.NewInternalError("gagal membuat token reset")
+	}
+
+	msg := notifier.Message{
+		UserID:   user.ID,
+		Username: user.Username,
+		Subject:  "Reset password",
+		Body: fmt.Sprintf(
+			"Gunakan token berikut untuk reset password: %s\nToken berlaku sampai %s dan hanya bisa dipakai sekali.",
+			token, reset.ExpiresAt.Format(time.RFC3339),
+		),
+		At: now,
+	}
+	if err := s.notifier.Send(msg); err != nil {
+		log.Printf("password reset notification for user %d failed: %v", user.ID, err)
+		return apperror.NewInternalError("gagal mengirim token reset")
+	}
+	return nil
+}
+
+func (s *authService) ResetPassword(token, newPassword string) error {
+	reset, err := s.resetRepo.Get(hashToken(token))
+	if err != nil || reset.UsedAt != nil || time.Now().After(reset.ExpiresAt) {
+		return apperror.NewValidationError("token reset tidak valid atau sudah kadaluarsa")
+	}
+
+	user, err := s.repo.GetByID(reset.UserID)
+	if err != nil {
+		return apperror.NewNotFoundError("user tidak ditemukan")
+	}
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	claimed, err := s.resetRepo.MarkUsed(reset.ID, now)
+	if err != nil {
+		return apperror.NewInternalError("gagal memproses token reset")
+	}
+	if !claimed {
+		return apperror.NewValidationError("token reset tidak valid atau sudah kadaluarsa")
+	}
+
+	if err := s.setPassword(user, newPassword); err != nil {
+		return err
+	}
+
+	if _, err := s.sessionRepo.RevokeByUserExcept(user.ID, 0, now); err != nil {
+		return apperror.NewInternalError("gagal mencabut sesi")
+	}
+	if err := s.rtRepo.RevokeByUser(user.ID, now); err != nil {
+		return apperror.NewInternalError("gagal mencabut refresh token")
+	}
+	return nil
+}
+
+func (s *authService) setPassword(user *models.User, password string) error {
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return apperror.NewInternalError("gagal proses password")
+	}
+
+	user.PasswordHash = string(hash)
 	if err := s.repo.Update(user); err != nil {
-		return apperror.NewInternalError("gagal update token version")
+		return apperror.NewInternalError("gagal menyimpan password")
+	}
+	return nil
+}
+
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return apperror.NewValidationError(fmt.Sprintf("password minimal %d karakter", minPasswordLength))
 	}
+	return nil
+}
 
-	if err := s.rtRepo.Delete(refreshToken); err != nil {
-		return apperror.NewInternalError("gagal menghapus refresh token")
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
 
+func (s *authService) PurgeExpiredTokens(now time.Time) error {
+	purged, err := s.rtRepo.DeleteExpired(now)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("purged %d expired refresh tokens", purged)
+	}
+
+	purged, err = s.resetRepo.DeleteExpired(now)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("purged %d expired password reset tokens", purged)
+	}
+
+	purged, err = s.challengeRepo.DeleteExpired(now)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("purged %d expired 2fa challenges", purged)
+	}
 	return nil
 }
+
+func (s *authService) endSession(sessionID uint) error {
+	if err := s.sessionRepo.Revoke(sessionID, time.Now()); err != nil {
+		return apperror.NewInternalError("gagal mencabut sesi")
+	}
+	if err := s.rtRepo.DeleteBySession(sessionID); err != nil {
+		return apperror.NewInternalError("gagal menghapus refresh token")
+	}
+	return nil
+}
+
+func (s *authService) Sessions(userID uint) ([]models.UserSession, error) {
+	list, err := s.sessionRepo.GetActiveByUser(userID, time.Now())
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil daftar sesi")
+	}
+	return list, nil
+}
+
+func (s *authService) RevokeSession(userID, sessionID uint) error {
+	session, err := s.sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != userID {
+		return apperror.NewNotFoundError("sesi tidak ditemukan")
+	}
+	if session.RevokedAt != nil {
+		return apperror.NewValidationError("sesi sudah dicabut")
+	}
+
+	return s.endSession(session.ID)
+}
+
+func (s *authService) RevokeOtherSessions(userID, currentSessionID uint) (int64, error) {
+	revoked, err := s.sessionRepo.RevokeByUserExcept(userID, currentSessionID, time.Now())
+	if err != nil {
+		return 0, apperror.NewInternalError("gagal mencabut sesi lain")
+	}
+	if err := s.rtRepo.DeleteByUserExceptSession(userID, currentSessionID); err != nil {
+		return 0, apperror.NewInternalError("gagal menghapus refresh token")
+	}
+	return revoked, nil
+}
+
+func (s *authService) JWKS() jwtkeys.JWKS {
+	return s.keys.JWKS()
+}
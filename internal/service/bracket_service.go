@@ -0,0 +1,661 @@
+package service
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"football-backend/internal/dto"
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+type BracketOptions struct {
+	SeasonID          uint
+	Name              string
+	TeamIDs           []uint
+	Pairs             [][2]uint
+	Seeded            bool
+	TwoLegged         bool
+	AwayGoalsRule     bool
+	ExtraTime         bool
+	StartDate         time.Time
+	RoundIntervalDays int
+	LegIntervalDays   int
+}
+
+type BracketService interface {
+	Create(opt BracketOptions) (*models.Bracket, error)
+	GetByID(id uint) (*models.Bracket, error)
+	GetBySeason(seasonID uint) ([]models.Bracket, error)
+	RecordPenalties(matchID uint, kicks []models.PenaltyKick, extraTime bool) error
+}
+
+type bracketService struct {
+	repo        repository.BracketRepository
+	matchRepo   repository.MatchRepository
+	teamRepo    repository.TeamRepository
+	playerRepo  repository.PlayerRepository
+	seasonRepo  repository.SeasonRepository
+	penaltyRepo repository.PenaltyKickRepository
+	uow         repository.UnitOfWork
+	venueWindow time.Duration
+}
+
+func NewBracketService(
+	r repository.BracketRepository,
+	mr repository.MatchRepository,
+	tr repository.TeamRepository,
+	pr repository.PlayerRepository,
+	sr repository.SeasonRepository,
+	pkr repository.PenaltyKickRepository,
+	uow repository.UnitOfWork,
+	venueWindow time.Duration,
+) BracketService {
+	return &bracketService{
+		repo:        r,
+		matchRepo:   mr,
+		teamRepo:    tr,
+		playerRepo:  pr,
+		seasonRepo:  sr,
+		penaltyRepo: pkr,
+		uow:         uow,
+		venueWindow: venueWindow,
+	}
+}
+
+func bracketServiceTx(r repository.Repositories, venueWindow time.Duration) *bracketService {
+	return &bracketService{
+		repo:        r.Brackets,
+		matchRepo:   r.Matches,
+		teamRepo:    r.Teams,
+		playerRepo:  r.Players,
+		seasonRepo:  r.Seasons,
+		penaltyRepo: r.PenaltyKicks,
+		venueWindow: venueWindow,
+	}
+}
+
+func nextPowerOfTwo(n int) int {
+	size := 1
+	for size < n {
+		size *= 2
+	}
+	return size
+}
+
+func seedOrder(slots int) []int {
+	order := []int{1}
+	for len(order) < slots {
+		m := len(order) * 2
+		next := make([]int, 0, m)
+		for _, s := range order {
+			next = append(next, s, m+1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+func drawFirstRound(teamIDs []uint, size int, seeded, twoLegged bool) [][2]uint {
+	byes := size - len(teamIDs)
+	slots := size / 2
+
+	if !seeded {
+		shuffled := append([]uint{}, teamIDs...)
+		rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		pairs := [][2]uint{}
+		for _, id := range shuffled[:byes] {
+			pairs = append(pairs, [2]uint{id, 0})
+		}
+		rest := shuffled[byes:]
+		for i := 0; i+1 < len(rest); i += 2 {
+			pairs = append(pairs, [2]uint{rest[i], rest[i+1]})
+		}
+		rand.Shuffle(len(pairs), func(i, j int) { pairs[i], pairs[j] = pairs[j], pairs[i] })
+		return pairs
+	}
+
+	ranked := [][2]uint{}
+	for _, id := range teamIDs[:byes] {
+		ranked = append(ranked, [2]uint{id, 0})
+	}
+
+	rest := teamIDs[byes:]
+	half := len(rest) / 2
+	seeds := rest[:half]
+	pot := append([]uint{}, rest[half:]...)
+	rand.Shuffle(len(pot), func(i, j int) { pot[i], pot[j] = pot[j], pot[i] })
+
+	for i, seed := range seeds {
+		if twoLegged {
+			ranked = append(ranked, [2]uint{pot[i], seed})
+		} else {
+			ranked = append(ranked, [2]uint{seed, pot[i]})
+		}
+	}
+
+	pairs := make([][2]uint, slots)
+	for pos, rank := range seedOrder(slots) {
+		pairs[pos] = ranked[rank-1]
+	}
+	return pairs
+}
+
+func (s *bracketService) Create(opt BracketOptions) (*models.Bracket, error) {
+	var bracketID uint
+	err := s.uow.Do(func(r repository.Repositories) error {
+		id, err := bracketServiceTx(r, s.venueWindow).create(opt)
+		bracketID = id
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(bracketID)
+}
+
+func (s *bracketService) create(opt BracketOptions) (uint, error) {
+	if opt.Name == "" {
+		return 0, apperror.NewValidationError("nama bracket wajib diisi")
+	}
+	if len(opt.TeamIDs) < 2 {
+		return 0, apperror.NewValidationError("minimal 2 tim untuk membuat bracket")
+	}
+	if opt.RoundIntervalDays < 1 {
+		return 0, apperror.NewValidationError("jarak antar babak minimal 1 hari")
+	}
+	if opt.TwoLegged && opt.LegIntervalDays < 1 {
+		return 0, apperror.NewValidationError("jarak antar leg minimal 1 hari")
+	}
+	if opt.TwoLegged && opt.LegIntervalDays >= opt.RoundIntervalDays {
+		return 0, apperror.NewValidationError("jarak antar leg harus lebih kecil dari jarak antar babak")
+	}
+
+	if _, err := s.seasonRepo.GetByID(opt.SeasonID); err != nil {
+		return 0, apperror.NewNotFoundError("musim tidak ditemukan")
+	}
+
+	seen := map[uint]bool{}
+	for _, id := range opt.TeamIDs {
+		if seen[id] {
+			return 0, apperror.NewValidationError(fmt.Sprintf("tim %d terdaftar lebih dari sekali", id))
+		}
+		seen[id] = true
+		if _, err := s.teamRepo.GetByID(id); err != nil {
+			return 0, apperror.NewNotFoundError(fmt.Sprintf("tim %d tidak ditemukan", id))
+		}
+	}
+
+	size := nextPowerOfTwo(len(opt.TeamIDs))
+	if len(opt.Pairs) > 0 && (len(opt.Pairs)*2 != size || size != len(opt.TeamIDs)) {
+		return 0, apperror.NewValidationError("jumlah pasangan undian tidak sesuai ukuran bracket")
+	}
+
+	rounds := 0
+	for n := size; n > 1; n /= 2 {
+		rounds++
+	}
+
+	bracket := &models.Bracket{
+		SeasonID:          opt.SeasonID,
+		Name:              opt.Name,
+		Size:              size,
+		Seeded:            opt.Seeded,
+		TwoLegged:         opt.TwoLegged,
+		AwayGoalsRule:     opt.AwayGoalsRule,
+		ExtraTime:         opt.ExtraTime,
+		StartDate:         opt.StartDate,
+		RoundIntervalDays: opt.RoundIntervalDays,
+		LegIntervalDays:   opt.LegIntervalDays,
+	}
+	if err := s.repo.Create(bracket); err != nil {
+		return 0, apperror.NewInternalError("gagal membuat bracket")
+	}
+
+	ties := map[int][]*models.KnockoutTie{}
+	for r := rounds; r >= 1; r-- {
+		count := size >> r
+		for p := 0; p < count; p++ {
+			tie := &models.KnockoutTie{
+				BracketID: bracket.ID,
+				Round:     r,
+				Position:  p,
+			}
+			if r < rounds {
+				next := ties[r+1][p/2]
+				tie.NextTieID = &next.ID
+				tie.NextSlot = "HOME"
+				if p%2 == 1 {
+					tie.NextSlot = "AWAY"
+				}
+			}
+			if err := s.repo.CreateTie(tie); err != nil {
+				return 0, apperror.NewInternalError("gagal membuat slot bracket")
+			}
+			ties[r] = append(ties[r], tie)
+		}
+	}
+
+	var pairs [][2]uint
+	if len(opt.Pairs) > 0 {
+		pairs = make([][2]uint, size/2)
+		for pos, rank := range seedOrder(size / 2) {
+			pairs[pos] = opt.Pairs[rank-1]
+		}
+	} else {
+		pairs = drawFirstRound(opt.TeamIDs, size, opt.Seeded, opt.TwoLegged)
+	}
+	for p, pair := range pairs {
+		tie := ties[1][p]
+		home := pair[0]
+		tie.HomeTeamID = &home
+
+		if pair[1] == 0 {
+			tie.WinnerTeamID = &home
+			tie.DecidedBy = "BYE"
+			if err := s.repo.UpdateTie(tie); err != nil {
+				return 0, apperror.NewInternalError("gagal menyimpan hasil undian")
+			}
+			if err := s.advance(bracket, tie); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		away := pair[1]
+		tie.AwayTeamID = &away
+		if err := s.repo.UpdateTie(tie); err != nil {
+			return 0, apperror.NewInternalError("gagal menyimpan hasil undian")
+		}
+		if err := s.scheduleTie(bracket, tie); err != nil {
+			return 0, err
+		}
+	}
+
+	return bracket.ID, nil
+}
+
+func (s *bracketService) GetByID(id uint) (*models.Bracket, error) {
+	b, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("bracket tidak ditemukan")
+	}
+	return b, nil
+}
+
+func (s *bracketService) GetBySeason(seasonID uint) ([]models.Bracket, error) {
+	list, err := s.repo.GetBySeason(seasonID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data bracket")
+	}
+	return list, nil
+}
+
+func (s *bracketService) scheduleTie(b *models.Bracket, tie *models.KnockoutTie) error {
+	legs := 1
+	if b.TwoLegged {
+		legs = 2
+	}
+
+	date := b.StartDate.AddDate(0, 0, (tie.Round-1)*b.RoundIntervalDays)
+	seasonID := b.SeasonID
+
+	for leg := 1; leg <= legs; leg++ {
+		home, away := *tie.HomeTeamID, *tie.AwayTeamID
+		if leg == 2 {
+			home, away = away, home
+			date = date.AddDate(0, 0, b.LegIntervalDays)
+		}
+
+		for _, teamID := range []uint{home, away} {
+			conflict, err := s.matchRepo.CheckConflict(teamID, date)
+			if err != nil {
+				return apperror.NewInternalError("gagal memeriksa jadwal")
+			}
+			if conflict {
+				return apperror.NewConflictError(fmt.Sprintf(
+					"jadwal babak %d bentrok dengan pertandingan lain tim %d", tie.Round, teamID,
+				))
+			}
+		}
+
+		homeTeam, err := s.teamRepo.GetByID(home)
+		if err != nil {
+			return apperror.NewNotFoundError(fmt.Sprintf("tim %d tidak ditemukan", home))
+		}
+
+		tieID := tie.ID
+		m := &models.Match{
+			MatchDateTime: date,
+			SeasonID:      &seasonID,
+			Round:         tie.Round,
+			TieID:         &tieID,
+			Leg:           leg,
+			HomeTeamID:    home,
+			AwayTeamID:    away,
+			VenueID:       homeTeam.HomeVenueID,
+			Status:        MatchScheduled,
+		}
+		if err := checkVenueConflict(s.matchRepo, s.venueWindow, m.VenueID, 0, date); err != nil {
+			return err
+		}
+		if err := s.matchRepo.Create(m); err != nil {
+			return apperror.NewInternalError("gagal membuat pertandingan knockout")
+		}
+	}
+	return nil
+}
+
+func (s *bracketService) advance(b *models.Bracket, tie *models.KnockoutTie) error {
+	if tie.NextTieID == nil || tie.WinnerTeamID == nil {
+		return nil
+	}
+
+	next, err := s.repo.GetTieByID(*tie.NextTieID)
+	if err != nil {
+		return apperror.NewInternalError("gagal mengambil babak berikutnya")
+	}
+
+	winner := *tie.WinnerTeamID
+	if tie.NextSlot == "AWAY" {
+		next.AwayTeamID = &winner
+	} else {
+		next.HomeTeamID = &winner
+	}
+
+	if err := s.repo.UpdateTie(next); err != nil {
+		return apperror.NewInternalError("gagal memperbarui babak berikutnya")
+	}
+
+	if next.HomeTeamID != nil && next.AwayTeamID != nil && len(next.Matches) == 0 {
+		return s.scheduleTie(b, next)
+	}
+	return nil
+}
+
+func isMatchDecided(status string) bool {
+	return status == MatchFinished
+}
+
+func matchScore(m *models.Match) (int, int) {
+	home := 0
+	away := 0
+	for _, g := range m.Goals {
+		if dto.ScoringTeamID(&g, m) == m.HomeTeamID {
+			home++
+		} else {
+			away++
+		}
+	}
+	return home, away
+}
+
+func minuteBase(minute string) int {
+	base, _ := strconv.Atoi(strings.Split(minute, "+")[0])
+	return base
+}
+
+func decideTie(tie *models.KnockoutTie) (uint, string) {
+	legs := 1
+	if tie.Bracket.TwoLegged {
+		legs = 2
+	}
+	if len(tie.Matches) < legs || tie.HomeTeamID == nil || tie.AwayTeamID == nil {
+		return 0, ""
+	}
+	for _, m := range tie.Matches {
+		if !isMatchDecided(m.Status) {
+			return 0, ""
+		}
+	}
+
+	a, b := *tie.HomeTeamID, *tie.AwayTeamID
+	goals := map[uint]int{}
+	awayGoals := map[uint]int{}
+
+	for i := range tie.Matches {
+		m := &tie.Matches[i]
+		h, aw := matchScore(m)
+		goals[m.HomeTeamID] += h
+		goals[m.AwayTeamID] += aw
+		awayGoals[m.AwayTeamID] += aw
+	}
+
+	decidedBy := "SKOR"
+	if legs == 2 {
+		decidedBy = "AGREGAT"
+	}
+	if goals[a] > goals[b] {
+		return a, decidedBy
+	}
+	if goals[b] > goals[a] {
+		return b, decidedBy
+	}
+
+	if legs == 2 && tie.Bracket.AwayGoalsRule {
+		if awayGoals[a] > awayGoals[b] {
+			return a, "GOL_TANDANG"
+		}
+		if awayGoals[b] > awayGoals[a] {
+			return b, "GOL_TANDANG"
+		}
+	}
+
+	last := &tie.Matches[len(tie.Matches)-1]
+	if tie.Bracket.ExtraTime && !last.ExtraTime {
+		return 0, ""
+	}
+
+	scored := map[uint]int{}
+	for _, k := range last.PenaltyKicks {
+		if k.Scored {
+			scored[k.TeamID]++
+		}
+	}
+	if scored[a] > scored[b] {
+		return a, "PENALTI"
+	}
+	if scored[b] > scored[a] {
+		return b, "PENALTI"
+	}
+
+	return 0, ""
+}
+
+func shootoutDecided(taken, scored map[uint]int, a, b uint) bool {
+	if taken[a] < 5 || taken[b] < 5 {
+		remA := max(5-taken[a], 0)
+		remB := max(5-taken[b], 0)
+		return scored[a] > scored[b]+remB || scored[b] > scored[a]+remA
+	}
+	return taken[a] == taken[b] && scored[a] != scored[b]
+}
+
+func validateShootout(kicks []models.PenaltyKick, a, b uint) error {
+	if len(kicks) == 0 {
+		return apperror.NewValidationError("data tendangan penalti wajib diisi")
+	}
+
+	sort.Slice(kicks, func(i, j int) bool { return kicks[i].KickOrder < kicks[j].KickOrder })
+
+	taken := map[uint]int{}
+	scored := map[uint]int{}
+	decided := false
+
+	for i, k := range kicks {
+		if k.KickOrder != i+1 {
+			return apperror.NewValidationError("urutan tendangan harus berurutan mulai dari 1")
+		}
+		if k.TeamID != a && k.TeamID != b {
+			return apperror.NewValidationError(fmt.Sprintf("tendangan ke-%d: tim tidak sesuai dengan tim yang bertanding", k.KickOrder))
+		}
+		if i > 0 && k.TeamID == kicks[i-1].TeamID {
+			return apperror.NewValidationError(fmt.Sprintf("tendangan ke-%d: tim harus menendang bergantian", k.KickOrder))
+		}
+		if decided {
+			return apperror.NewValidationError(fmt.Sprintf("tendangan ke-%d tidak diperlukan karena adu penalti sudah selesai", k.KickOrder))
+		}
+
+		taken[k.TeamID]++
+		if k.Scored {
+			scored[k.TeamID]++
+		}
+		decided = shootoutDecided(taken, scored, a, b)
+	}
+
+	if !decided {
+		return apperror.NewValidationError("adu penalti belum menghasilkan pemenang")
+	}
+	return nil
+}
+
+func (s *bracketService) RecordPenalties(matchID uint, kicks []models.PenaltyKick, extraTime bool) error {
+	return s.uow.Do(func(r repository.Repositories) error {
+		return bracketServiceTx(r, s.venueWindow).recordPenalties(matchID, kicks, extraTime)
+	})
+}
+
+func (s *bracketService) recordPenalties(matchID uint, kicks []models.PenaltyKick, extraTime bool) error {
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+	if match.TieID == nil {
+		return apperror.NewValidationError("adu penalti hanya untuk pertandingan knockout")
+	}
+	if !isMatchDecided(match.Status) {
+		return apperror.NewValidationError("hasil pertandingan belum diproses")
+	}
+
+	tie, err := s.repo.GetTieByID(*match.TieID)
+	if err != nil {
+		return apperror.NewNotFoundError("tie knockout tidak ditemukan")
+	}
+	if tie.WinnerTeamID != nil {
+		return apperror.NewConflictError("tie sudah memiliki pemenang")
+	}
+
+	legs := 1
+	if tie.Bracket.TwoLegged {
+		legs = 2
+	}
+	if match.Leg != legs {
+		return apperror.NewValidationError("adu penalti hanya dapat dicatat pada leg terakhir")
+	}
+
+	for _, m := range tie.Matches {
+		if !isMatchDecided(m.Status) {
+			return apperror.NewValidationError("semua leg harus selesai sebelum adu penalti")
+		}
+	}
+
+	if extraTime && !match.ExtraTime {
+		if !tie.Bracket.ExtraTime {
+			return apperror.NewValidationError("bracket ini tidak memakai extra time")
+		}
+		match.ExtraTime = true
+		if err := s.matchRepo.Update(match); err != nil {
+			return apperror.NewInternalError("gagal memperbarui pertandingan")
+		}
+		tie.Matches[len(tie.Matches)-1].ExtraTime = true
+	}
+
+	tie.Matches[len(tie.Matches)-1].PenaltyKicks = nil
+	if winner, _ := decideTie(tie); winner != 0 {
+		return apperror.NewValidationError("tie sudah ditentukan tanpa adu penalti")
+	}
+	if tie.Bracket.ExtraTime && !match.ExtraTime {
+		return apperror.NewValidationError("bracket mewajibkan extra time sebelum adu penalti, kirim extra_time: true jika sudah dimainkan")
+	}
+
+	for i := range kicks {
+		player, err := s.playerRepo.GetByID(kicks[i].PlayerID)
+		if err != nil {
+			return apperror.NewNotFoundError(fmt.Sprintf("pemain %d tidak ditemukan", kicks[i].PlayerID))
+		}
+		if player.TeamID != kicks[i].TeamID {
+			return apperror.NewValidationError(fmt.Sprintf("pemain %s bukan anggota tim penendang", player.Name))
+		}
+		kicks[i].MatchID = matchID
+	}
+
+	if err := validateShootout(kicks, match.HomeTeamID, match.AwayTeamID); err != nil {
+		return err
+	}
+
+	if err := s.penaltyRepo.ReplaceForMatch(matchID, kicks); err != nil {
+		return apperror.NewInternalError("gagal menyimpan adu penalti")
+	}
+
+	return s.resolveMatch(matchID)
+}
+
+func (s *bracketService) resolveMatch(matchID uint) error {
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+	if match.TieID == nil {
+		return nil
+	}
+
+	tie, err := s.repo.GetTieByID(*match.TieID)
+	if err != nil {
+		return apperror.NewNotFoundError("tie knockout tidak ditemukan")
+	}
+	if tie.WinnerTeamID != nil {
+		return nil
+	}
+
+	winner, decidedBy := decideTie(tie)
+	if winner == 0 {
+		return nil
+	}
+
+	tie.WinnerTeamID = &winner
+	tie.DecidedBy = decidedBy
+	if err := s.repo.UpdateTie(tie); err != nil {
+		return apperror.NewInternalError("gagal menyimpan pemenang tie")
+	}
+
+	return s.advance(&tie.Bracket, tie)
+}
+
+func (s *bracketService) reviseMatch(matchID uint) error {
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+	if match.TieID == nil {
+		return nil
+	}
+
+	tie, err := s.repo.GetTieByID(*match.TieID)
+	if err != nil {
+		return apperror.NewNotFoundError("tie knockout tidak ditemukan")
+	}
+	if tie.WinnerTeamID == nil {
+		return s.resolveMatch(matchID)
+	}
+
+	winner, decidedBy := decideTie(tie)
+	if winner != *tie.WinnerTeamID {
+		return apperror.NewValidationError("koreksi mengubah pemenang tie knockout yang sudah ditetapkan")
+	}
+	if decidedBy == tie.DecidedBy {
+		return nil
+	}
+
+	tie.DecidedBy = decidedBy
+	if err := s.repo.UpdateTie(tie); err != nil {
+		return apperror.NewInternalError("gagal menyimpan pemenang tie")
+	}
+	return nil
+}
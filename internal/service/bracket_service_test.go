@@ -0,0 +1,278 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"football-backend/internal/models"
+)
+
+func TestSeedOrder(t *testing.T) {
+	tests := []struct {
+		slots int
+		want  []int
+	}{
+		{1, []int{1}},
+		{2, []int{1, 2}},
+		{4, []int{1, 4, 2, 3}},
+		{8, []int{1, 8, 4, 5, 2, 7, 3, 6}},
+	}
+
+	for _, tt := range tests {
+		if got := seedOrder(tt.slots); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("seedOrder(%d) = %v, want %v", tt.slots, got, tt.want)
+		}
+	}
+}
+
+func TestDrawFirstRound(t *testing.T) {
+	tests := []struct {
+		name      string
+		teams     []uint
+		seeded    bool
+		twoLegged bool
+	}{
+		{"unseeded full", []uint{1, 2, 3, 4, 5, 6, 7, 8}, false, false},
+		{"unseeded with byes", []uint{1, 2, 3, 4, 5}, false, false},
+		{"seeded full", []uint{1, 2, 3, 4, 5, 6, 7, 8}, true, false},
+		{"seeded with byes", []uint{1, 2, 3, 4, 5, 6}, true, false},
+		{"seeded two legged", []uint{1, 2, 3, 4, 5, 6}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := nextPowerOfTwo(len(tt.teams))
+			byes := size - len(tt.teams)
+			pairs := drawFirstRound(tt.teams, size, tt.seeded, tt.twoLegged)
+
+			if len(pairs) != size/2 {
+				t.Fatalf("got %d pairs, want %d", len(pairs), size/2)
+			}
+
+			seen := map[uint]int{}
+			gotByes := 0
+			for _, p := range pairs {
+				if p[1] == 0 {
+					gotByes++
+				}
+				for _, id := range p {
+					if id != 0 {
+						seen[id]++
+					}
+				}
+			}
+			if gotByes != byes {
+				t.Errorf("got %d byes, want %d", gotByes, byes)
+			}
+			for _, id := range tt.teams {
+				if seen[id] != 1 {
+					t.Errorf("team %d drawn %d times, want 1", id, seen[id])
+				}
+			}
+
+			if !tt.seeded {
+				return
+			}
+
+			rank := map[uint]int{}
+			for i, id := range tt.teams {
+				rank[id] = i + 1
+			}
+			rest := len(tt.teams) - byes
+			for pos, seedRank := range seedOrder(size / 2) {
+				p := pairs[pos]
+				if seedRank <= byes {
+					if p != [2]uint{tt.teams[seedRank-1], 0} {
+						t.Errorf("slot %d = %v, want bye for seed %d", pos, p, seedRank)
+					}
+					continue
+				}
+
+				seed, opponent := p[0], p[1]
+				if tt.twoLegged {
+					seed, opponent = p[1], p[0]
+				}
+				if seed != tt.teams[seedRank-1] {
+					t.Errorf("slot %d = %v, want seed %d", pos, p, tt.teams[seedRank-1])
+				}
+				if rank[opponent] <= byes+rest/2 {
+					t.Errorf("slot %d: seed %d drawn against seeded team %d", pos, seed, opponent)
+				}
+			}
+		})
+	}
+}
+
+func testMatch(home, away uint, homeGoals, awayGoals int) models.Match {
+	m := models.Match{HomeTeamID: home, AwayTeamID: away, Status: MatchFinished}
+	for i := 0; i < homeGoals; i++ {
+		m.Goals = append(m.Goals, models.Goal{TeamID: home, Type: "BIASA", Minute: "10"})
+	}
+	for i := 0; i < awayGoals; i++ {
+		m.Goals = append(m.Goals, models.Goal{TeamID: away, Type: "BIASA", Minute: "20"})
+	}
+	return m
+}
+
+func testShootout(a, b uint, scoredA, scoredB []bool) []models.PenaltyKick {
+	kicks := []models.PenaltyKick{}
+	for i := 0; i < len(scoredA) || i < len(scoredB); i++ {
+		if i < len(scoredA) {
+			kicks = append(kicks, models.PenaltyKick{TeamID: a, Scored: scoredA[i], KickOrder: len(kicks) + 1})
+		}
+		if i < len(scoredB) {
+			kicks = append(kicks, models.PenaltyKick{TeamID: b, Scored: scoredB[i], KickOrder: len(kicks) + 1})
+		}
+	}
+	return kicks
+}
+
+func TestDecideTie(t *testing.T) {
+	const a, b uint = 1, 2
+
+	withKicks := func(m models.Match, kicks []models.PenaltyKick) models.Match {
+		m.PenaltyKicks = kicks
+		return m
+	}
+	withExtraTime := func(m models.Match) models.Match {
+		m.ExtraTime = true
+		return m
+	}
+	unfinished := testMatch(a, b, 1, 0)
+	unfinished.Status = MatchLive
+	ownGoal := models.Match{
+		HomeTeamID: a, AwayTeamID: b, Status: MatchFinished,
+		Goals: []models.Goal{{TeamID: a, Type: "BUNUH_DIRI", Minute: "30"}},
+	}
+	aWinsShootout := testShootout(a, b, []bool{true, true, true, true}, []bool{true, false, true, false})
+	bWinsShootout := testShootout(a, b, []bool{false, true, false}, []bool{true, true, true})
+
+	tests := []struct {
+		name          string
+		bracket       models.Bracket
+		matches       []models.Match
+		wantWinner    uint
+		wantDecidedBy string
+	}{
+		{
+			name:          "single leg score",
+			matches:       []models.Match{testMatch(a, b, 2, 1)},
+			wantWinner:    a,
+			wantDecidedBy: "SKOR",
+		},
+		{
+			name:          "own goal counts for opponent",
+			matches:       []models.Match{ownGoal},
+			wantWinner:    b,
+			wantDecidedBy: "SKOR",
+		},
+		{
+			name:    "match not finished",
+			matches: []models.Match{unfinished},
+		},
+		{
+			name:    "second leg missing",
+			bracket: models.Bracket{TwoLegged: true},
+			matches: []models.Match{testMatch(a, b, 1, 0)},
+		},
+		{
+			name:          "aggregate",
+			bracket:       models.Bracket{TwoLegged: true},
+			matches:       []models.Match{testMatch(a, b, 2, 0), testMatch(b, a, 1, 0)},
+			wantWinner:    a,
+			wantDecidedBy: "AGREGAT",
+		},
+		{
+			name:          "away goals",
+			bracket:       models.Bracket{TwoLegged: true, AwayGoalsRule: true},
+			matches:       []models.Match{testMatch(a, b, 1, 2), testMatch(b, a, 0, 1)},
+			wantWinner:    b,
+			wantDecidedBy: "GOL_TANDANG",
+		},
+		{
+			name:    "level aggregate without shootout",
+			bracket: models.Bracket{TwoLegged: true},
+			matches: []models.Match{testMatch(a, b, 1, 2), testMatch(b, a, 0, 1)},
+		},
+		{
+			name:          "shootout without extra time rule",
+			matches:       []models.Match{withKicks(testMatch(a, b, 1, 1), aWinsShootout)},
+			wantWinner:    a,
+			wantDecidedBy: "PENALTI",
+		},
+		{
+			name:    "shootout before required extra time",
+			bracket: models.Bracket{ExtraTime: true},
+			matches: []models.Match{withKicks(testMatch(a, b, 1, 1), aWinsShootout)},
+		},
+		{
+			name:          "shootout after extra time",
+			bracket:       models.Bracket{ExtraTime: true},
+			matches:       []models.Match{withExtraTime(withKicks(testMatch(a, b, 1, 1), bWinsShootout))},
+			wantWinner:    b,
+			wantDecidedBy: "PENALTI",
+		},
+		{
+			name:          "two legged shootout on last leg",
+			bracket:       models.Bracket{TwoLegged: true, ExtraTime: true},
+			matches:       []models.Match{testMatch(a, b, 1, 0), withExtraTime(withKicks(testMatch(b, a, 1, 0), bWinsShootout))},
+			wantWinner:    b,
+			wantDecidedBy: "PENALTI",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home, away := a, b
+			tie := &models.KnockoutTie{
+				Bracket:    tt.bracket,
+				HomeTeamID: &home,
+				AwayTeamID: &away,
+				Matches:    tt.matches,
+			}
+
+			winner, decidedBy := decideTie(tie)
+			if winner != tt.wantWinner || decidedBy != tt.wantDecidedBy {
+				t.Errorf("decideTie() = (%d, %q), want (%d, %q)", winner, decidedBy, tt.wantWinner, tt.wantDecidedBy)
+			}
+		})
+	}
+}
+
+func TestValidateShootout(t *testing.T) {
+	const a, b uint = 1, 2
+
+	sameTeamTwice := testShootout(a, b, []bool{true}, []bool{true})
+	sameTeamTwice[1].TeamID = a
+	wrongStart := testShootout(a, b, []bool{true, true, true}, []bool{false, false, false})
+	for i := range wrongStart {
+		wrongStart[i].KickOrder++
+	}
+	unknownTeam := testShootout(a, b, []bool{true}, []bool{true})
+	unknownTeam[1].TeamID = 3
+
+	tests := []struct {
+		name    string
+		kicks   []models.PenaltyKick
+		wantErr bool
+	}{
+		{"empty", nil, true},
+		{"decided after five each", testShootout(a, b, []bool{true, true, true, true, true}, []bool{true, true, true, true, false}), false},
+		{"decided early", testShootout(a, b, []bool{true, true, true}, []bool{false, false, false}), false},
+		{"kick after decision", testShootout(a, b, []bool{true, true, true, true}, []bool{false, false, false}), true},
+		{"sudden death", testShootout(a, b, []bool{true, true, true, true, true, true}, []bool{true, true, true, true, true, false}), false},
+		{"still level", testShootout(a, b, []bool{true, true, true, true, true}, []bool{true, true, true, true, true}), true},
+		{"same team twice", sameTeamTwice, true},
+		{"order not starting at one", wrongStart, true},
+		{"team not in match", unknownTeam, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateShootout(tt.kicks, a, b)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateShootout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,469 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+	"football-backend/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+type CompetitionService interface {
+	Create(c *models.Competition) error
+	GetList(q utils.QueryParams) (map[string]interface{}, error)
+	GetByID(id uint) (*models.Competition, error)
+	Update(c *models.Competition) error
+	Delete(id uint) error
+
+	CreateSeason(s *models.Season) error
+	GetSeasons(competitionID uint) ([]models.Season, error)
+	GetSeason(competitionID, seasonID uint) (*models.Season, error)
+	UpdateSeason(s *models.Season) error
+	DeleteSeason(competitionID, seasonID uint) error
+
+	GetStandingRule(competitionID uint) (*models.StandingRule, error)
+	UpdateStandingRule(rule *models.StandingRule) error
+
+	GetSquadRule(competitionID uint) (*models.SquadRule, error)
+	UpdateSquadRule(rule *models.SquadRule) error
+
+	GetDisciplineRule(competitionID uint) (*models.DisciplineRule, error)
+	UpdateDisciplineRule(rule *models.DisciplineRule) error
+
+	AddSanction(competitionID uint, sanction *models.TeamSanction) error
+	GetSanctions(competitionID, seasonID uint) ([]models.TeamSanction, error)
+	DeleteSanction(competitionID, seasonID, sanctionID uint) error
+
+	AddTransferWindow(competitionID uint, w *models.TransferWindow) error
+	GetTransferWindows(competitionID, seasonID uint) ([]models.TransferWindow, error)
+	DeleteTransferWindow(competitionID, seasonID, windowID uint) error
+}
+
+type competitionService struct {
+	repo           repository.CompetitionRepository
+	seasonRepo     repository.SeasonRepository
+	matchRepo      repository.MatchRepository
+	teamRepo       repository.TeamRepository
+	ruleRepo       repository.StandingRuleRepository
+	sanctionRepo   repository.TeamSanctionRepository
+	windowRepo     repository.TransferWindowRepository
+	squadRepo      repository.SquadRuleRepository
+	disciplineRepo repository.DisciplineRuleRepository
+}
+
+func NewCompetitionService(
+	r repository.CompetitionRepository,
+	sr repository.SeasonRepository,
+	mr repository.MatchRepository,
+	tr repository.TeamRepository,
+	rr repository.StandingRuleRepository,
+	tsr repository.TeamSanctionRepository,
+	twr repository.TransferWindowRepository,
+	sqr repository.SquadRuleRepository,
+	dr repository.DisciplineRuleRepository,
+) CompetitionService {
+	return &competitionService{
+		repo:           r,
+		seasonRepo:     sr,
+		matchRepo:      mr,
+		teamRepo:       tr,
+		ruleRepo:       rr,
+		sanctionRepo:   tsr,
+		windowRepo:     twr,
+		squadRepo:      sqr,
+		disciplineRepo: dr,
+	}
+}
+
+func validateCompetitionType(t string) bool {
+	valid := map[string]bool{
+		"LIGA":     true,
+		"PIALA":    true,
+		"TURNAMEN": true,
+	}
+	return valid[t]
+}
+
+func (s *competitionService) Create(c *models.Competition) error {
+	if c.Name == "" {
+		return apperror.NewValidationError("nama kompetisi wajib diisi")
+	}
+
+	c.Type = strings.ToUpper(c.Type)
+	if c.Type == "" {
+		c.Type = "LIGA"
+	}
+	if !validateCompetitionType(c.Type) {
+		return apperror.NewValidationError("tipe kompetisi tidak valid (LIGA, PIALA, TURNAMEN)")
+	}
+
+	if err := s.repo.Create(c); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return apperror.NewConflictError("nama kompetisi sudah digunakan")
+		}
+		return apperror.NewInternalError("gagal membuat kompetisi")
+	}
+	return nil
+}
+
+func (s *competitionService) GetList(q utils.QueryParams) (map[string]interface{}, error) {
+	items, total, err := s.repo.GetAll(q)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data kompetisi")
+	}
+
+	totalPages := 0
+	if q.Limit > 0 {
+		totalPages = int((total + int64(q.Limit) - 1) / int64(q.Limit))
+	}
+
+	return map[string]interface{}{
+		"items": items,
+		"pagination": map[string]interface{}{
+			"page":        q.Page,
+			"limit":       q.Limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	}, nil
+}
+
+func (s *competitionService) GetByID(id uint) (*models.Competition, error) {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+	return c, nil
+}
+
+func (s *competitionService) Update(c *models.Competition) error {
+	if c.Name == "" {
+		return apperror.NewValidationError("nama kompetisi wajib diisi")
+	}
+
+	c.Type = strings.ToUpper(c.Type)
+	if !validateCompetitionType(c.Type) {
+		return apperror.NewValidationError("tipe kompetisi tidak valid (LIGA, PIALA, TURNAMEN)")
+	}
+
+	if err := s.repo.Update(c); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return apperror.NewConflictError("nama kompetisi sudah digunakan")
+		}
+		return apperror.NewInternalError("gagal memperbarui kompetisi")
+	}
+	return nil
+}
+
+func (s *competitionService) Delete(id uint) error {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+	if len(c.Seasons) > 0 {
+		return apperror.NewConflictError("kompetisi masih memiliki musim, hapus musim terlebih dahulu")
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return apperror.NewInternalError("gagal menghapus kompetisi")
+	}
+	return nil
+}
+
+func (s *competitionService) validateSeason(season *models.Season) error {
+	if season.Name == "" {
+		return apperror.NewValidationError("nama musim wajib diisi")
+	}
+	if season.StartDate.IsZero() || season.EndDate.IsZero() {
+		return apperror.NewValidationError("start_date dan end_date wajib diisi")
+	}
+	if !season.EndDate.After(season.StartDate) {
+		return apperror.NewValidationError("end_date harus setelah start_date")
+	}
+	return nil
+}
+
+func (s *competitionService) CreateSeason(season *models.Season) error {
+	if _, err := s.repo.GetByID(season.CompetitionID); err != nil {
+		return apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+
+	if err := s.validateSeason(season); err != nil {
+		return err
+	}
+
+	if err := s.seasonRepo.Create(season); err != nil {
+		return apperror.NewInternalError("gagal membuat musim")
+	}
+
+	if season.IsCurrent {
+		if err := s.seasonRepo.ClearCurrent(season.CompetitionID, season.ID); err != nil {
+			return apperror.NewInternalError("gagal memperbarui musim berjalan")
+		}
+	}
+	return nil
+}
+
+func (s *competitionService) GetSeasons(competitionID uint) ([]models.Season, error) {
+	if _, err := s.repo.GetByID(competitionID); err != nil {
+		return nil, apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+
+	list, err := s.seasonRepo.GetByCompetition(competitionID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data musim")
+	}
+	return list, nil
+}
+
+func (s *competitionService) GetSeason(competitionID, seasonID uint) (*models.Season, error) {
+	season, err := s.seasonRepo.GetByID(seasonID)
+	if err != nil || season.CompetitionID != competitionID {
+		return nil, apperror.NewNotFoundError("musim tidak ditemukan")
+	}
+	return season, nil
+}
+
+func (s *competitionService) UpdateSeason(season *models.Season) error {
+	if err := s.validateSeason(season); err != nil {
+		return err
+	}
+
+	if err := s.seasonRepo.Update(season); err != nil {
+		return apperror.NewInternalError("gagal memperbarui musim")
+	}
+
+	if season.IsCurrent {
+		if err := s.seasonRepo.ClearCurrent(season.CompetitionID, season.ID); err != nil {
+			return apperror.NewInternalError("gagal memperbarui musim berjalan")
+		}
+	}
+	return nil
+}
+
+func (s *competitionService) DeleteSeason(competitionID, seasonID uint) error {
+	if _, err := s.GetSeason(competitionID, seasonID); err != nil {
+		return err
+	}
+
+	count, err := s.matchRepo.CountBySeason(seasonID)
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa pertandingan musim")
+	}
+	if count > 0 {
+		return apperror.NewConflictError("musim masih memiliki pertandingan dan tidak dapat dihapus")
+	}
+
+	if err := s.seasonRepo.Delete(seasonID); err != nil {
+		return apperror.NewInternalError("gagal menghapus musim")
+	}
+	return nil
+}
+
+func (s *competitionService) GetStandingRule(competitionID uint) (*models.StandingRule, error) {
+	if _, err := s.repo.GetByID(competitionID); err != nil {
+		return nil, apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+
+	rule, err := s.ruleRepo.GetByCompetition(competitionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		def := defaultStandingRule()
+		def.CompetitionID = competitionID
+		return &def, nil
+	}
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil aturan klasemen")
+	}
+	return rule, nil
+}
+
+func (s *competitionService) UpdateStandingRule(rule *models.StandingRule) error {
+	if rule.PointsWin <= rule.PointsDraw || rule.PointsDraw < rule.PointsLoss {
+		return apperror.NewValidationError("poin menang harus lebih besar dari seri, dan seri tidak boleh lebih kecil dari kalah")
+	}
+
+	seen := map[string]bool{}
+	list := []string{}
+	for _, t := range parseTiebreakers(rule.Tiebreakers) {
+		t = strings.ToUpper(t)
+		if !validTiebreakers[t] {
+			return apperror.NewValidationError("tiebreaker tidak dikenal: " + t)
+		}
+		if seen[t] {
+			return apperror.NewValidationError("tiebreaker duplikat: " + t)
+		}
+		seen[t] = true
+		list = append(list, t)
+	}
+	rule.Tiebreakers = strings.Join(list, ",")
+
+	if err := s.ruleRepo.Save(rule); err != nil {
+		return apperror.NewInternalError("gagal menyimpan aturan klasemen")
+	}
+	return nil
+}
+
+func (s *competitionService) GetSquadRule(competitionID uint) (*models.SquadRule, error) {
+	if _, err := s.repo.GetByID(competitionID); err != nil {
+		return nil, apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+
+	rule, err := s.squadRepo.GetByCompetition(competitionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &models.SquadRule{CompetitionID: competitionID}, nil
+	}
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil aturan skuad")
+	}
+	return rule, nil
+}
+
+func (s *competitionService) UpdateSquadRule(rule *models.SquadRule) error {
+	if rule.MaxSquadSize < 0 || rule.MaxForeignPlayers < 0 {
+		return apperror.NewValidationError("batas skuad dan pemain asing tidak boleh negatif")
+	}
+	if rule.MaxSquadSize > 0 && rule.MaxForeignPlayers > rule.MaxSquadSize {
+		return apperror.NewValidationError("batas pemain asing tidak boleh melebihi batas skuad")
+	}
+
+	if err := s.squadRepo.Save(rule); err != nil {
+		return apperror.NewInternalError("gagal menyimpan aturan skuad")
+	}
+	return nil
+}
+
+func (s *competitionService) GetDisciplineRule(competitionID uint) (*models.DisciplineRule, error) {
+	if _, err := s.repo.GetByID(competitionID); err != nil {
+		return nil, apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+
+	rule, err := s.disciplineRepo.GetByCompetition(competitionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		def := defaultDisciplineRule()
+		def.CompetitionID = competitionID
+		return &def, nil
+	}
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil aturan disiplin")
+	}
+	return rule, nil
+}
+
+func (s *competitionService) UpdateDisciplineRule(rule *models.DisciplineRule) error {
+	if rule.YellowCardThreshold < 0 || rule.YellowCardBan < 0 || rule.SecondYellowBan < 0 || rule.RedCardBan < 0 {
+		return apperror.NewValidationError("aturan disiplin tidak boleh bernilai negatif")
+	}
+
+	if err := s.disciplineRepo.Save(rule); err != nil {
+		return apperror.NewInternalError("gagal menyimpan aturan disiplin")
+	}
+	return nil
+}
+
+func (s *competitionService) AddSanction(competitionID uint, sanction *models.TeamSanction) error {
+	if _, err := s.GetSeason(competitionID, sanction.SeasonID); err != nil {
+		return err
+	}
+	if _, err := s.teamRepo.GetByID(sanction.TeamID); err != nil {
+		return apperror.NewNotFoundError("team tidak ditemukan")
+	}
+	if sanction.PointsDeducted < 0 || sanction.FairPlayPoints < 0 {
+		return apperror.NewValidationError("pengurangan poin dan poin fair play tidak boleh negatif")
+	}
+	if sanction.PointsDeducted == 0 && sanction.FairPlayPoints == 0 {
+		return apperror.NewValidationError("sanksi harus berisi pengurangan poin atau poin fair play")
+	}
+	if sanction.Reason == "" {
+		return apperror.NewValidationError("alasan sanksi wajib diisi")
+	}
+
+	if err := s.sanctionRepo.Create(sanction); err != nil {
+		return apperror.NewInternalError("gagal menyimpan sanksi")
+	}
+	return nil
+}
+
+func (s *competitionService) GetSanctions(competitionID, seasonID uint) ([]models.TeamSanction, error) {
+	if _, err := s.GetSeason(competitionID, seasonID); err != nil {
+		return nil, err
+	}
+
+	list, err := s.sanctionRepo.GetBySeason(seasonID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data sanksi")
+	}
+	return list, nil
+}
+
+func (s *competitionService) DeleteSanction(competitionID, seasonID, sanctionID uint) error {
+	if _, err := s.GetSeason(competitionID, seasonID); err != nil {
+		return err
+	}
+
+	sanction, err := s.sanctionRepo.GetByID(sanctionID)
+	if err != nil || sanction.SeasonID != seasonID {
+		return apperror.NewNotFoundError("sanksi tidak ditemukan")
+	}
+
+	if err := s.sanctionRepo.Delete(sanctionID); err != nil {
+		return apperror.NewInternalError("gagal menghapus sanksi")
+	}
+	return nil
+}
+
+func (s *competitionService) AddTransferWindow(competitionID uint, w *models.TransferWindow) error {
+	if _, err := s.GetSeason(competitionID, w.SeasonID); err != nil {
+		return err
+	}
+	if w.Name == "" {
+		return apperror.NewValidationError("nama jendela transfer wajib diisi")
+	}
+	if !w.ClosesAt.After(w.OpensAt) {
+		return apperror.NewValidationError("closes_at harus setelah opens_at")
+	}
+
+	overlap, err := s.windowRepo.HasOverlap(w.SeasonID, w.OpensAt, w.ClosesAt)
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa jendela transfer")
+	}
+	if overlap {
+		return apperror.NewConflictError("jendela transfer bertabrakan dengan jendela lain di musim ini")
+	}
+
+	if err := s.windowRepo.Create(w); err != nil {
+		return apperror.NewInternalError("gagal menyimpan jendela transfer")
+	}
+	return nil
+}
+
+func (s *competitionService) GetTransferWindows(competitionID, seasonID uint) ([]models.TransferWindow, error) {
+	if _, err := s.GetSeason(competitionID, seasonID); err != nil {
+		return nil, err
+	}
+
+	list, err := s.windowRepo.GetBySeason(seasonID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil jendela transfer")
+	}
+	return list, nil
+}
+
+func (s *competitionService) DeleteTransferWindow(competitionID, seasonID, windowID uint) error {
+	if _, err := s.GetSeason(competitionID, seasonID); err != nil {
+		return err
+	}
+
+	window, err := s.windowRepo.GetByID(windowID)
+	if err != nil || window.SeasonID != seasonID {
+		return apperror.NewNotFoundError("jendela transfer tidak ditemukan")
+	}
+
+	if err := s.windowRepo.Delete(windowID); err != nil {
+		return apperror.NewInternalError("gagal menghapus jendela transfer")
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+type FixtureOptions struct {
+	SeasonID     uint
+	GroupID      *uint
+	TeamIDs      []uint
+	StartDate    time.Time
+	IntervalDays int
+	DoubleRound  bool
+	DryRun       bool
+}
+
+func buildRoundRobin(teamIDs []uint, double bool) [][][2]uint {
+	ids := append([]uint{}, teamIDs...)
+	if len(ids)%2 == 1 {
+		ids = append([]uint{0}, ids...)
+	}
+	n := len(ids)
+
+	rounds := [][][2]uint{}
+	for r := 0; r < n-1; r++ {
+		pairs := [][2]uint{}
+		for i := 0; i < n/2; i++ {
+			home, away := ids[i], ids[n-1-i]
+			if i == 0 {
+				if r%2 == 1 {
+					home, away = away, home
+				}
+			} else if i%2 == 1 {
+				home, away = away, home
+			}
+			if home == 0 || away == 0 {
+				continue
+			}
+			pairs = append(pairs, [2]uint{home, away})
+		}
+		rounds = append(rounds, pairs)
+
+		last := ids[n-1]
+		copy(ids[2:], ids[1:n-1])
+		ids[1] = last
+	}
+
+	if double {
+		first := len(rounds)
+		for r := 0; r < first; r++ {
+			pairs := [][2]uint{}
+			for _, p := range rounds[r] {
+				pairs = append(pairs, [2]uint{p[1], p[0]})
+			}
+			rounds = append(rounds, pairs)
+		}
+	}
+
+	return rounds
+}
+
+func (s *matchService) GenerateFixtures(opt FixtureOptions) ([]models.Match, error) {
+	var fixtures []models.Match
+	err := s.uow.Do(func(r repository.Repositories) error {
+		var err error
+		fixtures, err = matchServiceTx(r, s.venueWindow).generateFixtures(opt)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+func (s *matchService) generateFixtures(opt FixtureOptions) ([]models.Match, error) {
+	if opt.SeasonID == 0 {
+		return nil, apperror.NewValidationError("season_id wajib diisi")
+	}
+	if len(opt.TeamIDs) < 2 {
+		return nil, apperror.NewValidationError("minimal 2 tim untuk membuat jadwal")
+	}
+	if opt.IntervalDays < 1 {
+		return nil, apperror.NewValidationError("jarak antar matchday minimal 1 hari")
+	}
+
+	teams := map[uint]models.Team{}
+	for _, id := range opt.TeamIDs {
+		if _, ok := teams[id]; ok {
+			return nil, apperror.NewValidationError(fmt.Sprintf("tim %d terdaftar lebih dari sekali", id))
+		}
+		team, err := s.teamRepo.GetByID(id)
+		if err != nil {
+			return nil, apperror.NewNotFoundError(fmt.Sprintf("tim %d tidak ditemukan", id))
+		}
+		teams[id] = *team
+	}
+
+	rounds := buildRoundRobin(opt.TeamIDs, opt.DoubleRound)
+
+	lastRound := opt.StartDate.AddDate(0, 0, (len(rounds)-1)*opt.IntervalDays)
+	if err := s.validateSeason(opt.SeasonID, opt.StartDate); err != nil {
+		return nil, err
+	}
+	if err := s.validateSeason(opt.SeasonID, lastRound); err != nil {
+		return nil, err
+	}
+
+	seasonID := opt.SeasonID
+	fixtures := []models.Match{}
+
+	for r, pairs := range rounds {
+		date := opt.StartDate.AddDate(0, 0, r*opt.IntervalDays)
+
+		for _, p := range pairs {
+			for _, teamID := range p {
+				conflict, err := s.repo.CheckConflict(teamID, date)
+				if err != nil {
+					return nil, apperror.NewInternalError("gagal memeriksa jadwal")
+				}
+				if conflict {
+					return nil, apperror.NewConflictError(fmt.Sprintf(
+						"jadwal bentrok di matchday %d untuk tim %s", r+1, teams[teamID].Name,
+					))
+				}
+			}
+
+			home := teams[p[0]]
+			if home.HomeVenueID != nil {
+				if err := s.checkVenueConflict(home.HomeVenueID, 0, date); err != nil {
+					return nil, err
+				}
+				for _, f := range fixtures {
+					if f.VenueID != nil && *f.VenueID == *home.HomeVenueID && s.venueOverlaps(f.MatchDateTime, date) {
+						return nil, apperror.NewConflictError(fmt.Sprintf(
+							"jadwal venue bentrok di matchday %d: %s dipakai %s dan %s", r+1, home.HomeVenue.Name, f.HomeTeam.Name, home.Name,
+						))
+					}
+				}
+			}
+
+			fixtures = append(fixtures, models.Match{
+				MatchDateTime: date,
+				SeasonID:      &seasonID,
+				GroupID:       opt.GroupID,
+				Round:         r + 1,
+				HomeTeamID:    p[0],
+				AwayTeamID:    p[1],
+				HomeTeam:      teams[p[0]],
+				AwayTeam:      teams[p[1]],
+				VenueID:       home.HomeVenueID,
+				Venue:         home.HomeVenue,
+				Status:        MatchScheduled,
+			})
+		}
+	}
+
+	if opt.DryRun {
+		return fixtures, nil
+	}
+
+	for i := range fixtures {
+		m := fixtures[i]
+		m.HomeTeam = models.Team{}
+		m.AwayTeam = models.Team{}
+		m.Venue = nil
+
+		if err := s.repo.Create(&m); err != nil {
+			return nil, apperror.NewInternalError("gagal menyimpan jadwal pertandingan")
+		}
+		fixtures[i].ID = m.ID
+	}
+
+	return fixtures, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import "testing"
+
+func TestBuildRoundRobin(t *testing.T) {
+	tests := []struct {
+		name       string
+		teams      []uint
+		double     bool
+		wantRounds int
+		wantPairs  int
+	}{
+		{"two teams", []uint{1, 2}, false, 1, 1},
+		{"even single", []uint{1, 2, 3, 4}, false, 3, 2},
+		{"odd single", []uint{1, 2, 3, 4, 5}, false, 5, 2},
+		{"even double", []uint{1, 2, 3, 4, 5, 6}, true, 10, 3},
+		{"odd double", []uint{1, 2, 3}, true, 6, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rounds := buildRoundRobin(tt.teams, tt.double)
+			if len(rounds) != tt.wantRounds {
+				t.Fatalf("got %d rounds, want %d", len(rounds), tt.wantRounds)
+			}
+
+			fixtures := map[[2]uint]int{}
+			home := map[uint]int{}
+			away := map[uint]int{}
+			for r, pairs := range rounds {
+				if len(pairs) != tt.wantPairs {
+					t.Errorf("round %d has %d pairs, want %d", r+1, len(pairs), tt.wantPairs)
+				}
+
+				playing := map[uint]bool{}
+				for _, p := range pairs {
+					if p[0] == 0 || p[1] == 0 || p[0] == p[1] {
+						t.Errorf("round %d has invalid pair %v", r+1, p)
+					}
+					for _, id := range p {
+						if playing[id] {
+							t.Errorf("team %d plays twice in round %d", id, r+1)
+						}
+						playing[id] = true
+					}
+					fixtures[p]++
+					home[p[0]]++
+					away[p[1]]++
+				}
+			}
+
+			for i, x := range tt.teams {
+				for _, y := range tt.teams[i+1:] {
+					xy, yx := fixtures[[2]uint{x, y}], fixtures[[2]uint{y, x}]
+					if tt.double && (xy != 1 || yx != 1) {
+						t.Errorf("teams %d and %d meet %d/%d times home/away, want 1/1", x, y, xy, yx)
+					}
+					if !tt.double && xy+yx != 1 {
+						t.Errorf("teams %d and %d meet %d times, want 1", x, y, xy+yx)
+					}
+				}
+			}
+
+			for _, id := range tt.teams {
+				diff := home[id] - away[id]
+				if diff < -1 || diff > 1 {
+					t.Errorf("team %d has %d home and %d away matches", id, home[id], away[id])
+				}
+			}
+		})
+	}
+}
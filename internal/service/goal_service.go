@@ -5,74 +5,109 @@ import (
 	apperror "football-backend/internal/errors"
 	"football-backend/internal/models"
 	"football-backend/internal/repository"
-	"regexp"
 )
 
 type GoalService interface {
 	AddGoal(g *models.Goal) error
+	UpdateGoal(goalID uint, g *models.Goal, userID uint, reason string) error
+	DeleteGoal(goalID uint, userID uint, reason string) error
 	GetGoals(matchID uint) ([]models.Goal, error)
-	TopScorers(limit int) ([]dto.TopScorerDTO, error)
+	TopScorers(seasonID uint, limit int) ([]dto.TopScorerDTO, error)
 }
 
 type goalService struct {
-	repo      repository.GoalRepository
-	matchRepo repository.MatchRepository
+	repo     repository.GoalRepository
+	eventSvc MatchEventService
 }
 
 func NewGoalService(
 	goalRepo repository.GoalRepository,
-	matchRepo repository.MatchRepository,
+	eventSvc MatchEventService,
 ) GoalService {
 	return &goalService{
-		repo:      goalRepo,
-		matchRepo: matchRepo,
+		repo:     goalRepo,
+		eventSvc: eventSvc,
 	}
 }
 
 func (s *goalService) AddGoal(g *models.Goal) error {
-	if g.ScorerPlayerID == 0 {
-		return apperror.NewValidationError("pencetak gol wajib diisi")
-	}
-	if g.MatchID == 0 {
-		return apperror.NewValidationError("match_id wajib diisi")
+	event, err := goalEvent(g)
+	if err != nil {
+		return err
 	}
-	if g.TeamID == 0 {
-		return apperror.NewValidationError("team_id wajib diisi")
+
+	if err := s.eventSvc.Record(&event); err != nil {
+		return err
 	}
 
-	match, err := s.matchRepo.GetByID(g.MatchID)
+	g.ID = *event.GoalID
+	return nil
+}
+
+func (s *goalService) UpdateGoal(goalID uint, g *models.Goal, userID uint, reason string) error {
+	current, err := s.eventSvc.GoalEvent(goalID)
 	if err != nil {
-		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+		return err
 	}
 
-	if g.TeamID != match.HomeTeamID && g.TeamID != match.AwayTeamID {
-		return apperror.NewValidationError("team pencetak gol tidak sesuai dengan tim yang bertanding")
+	g.MatchID = current.MatchID
+	if g.Type == "" {
+		g.Type = goalTypeByEvent[current.Type]
+	}
+	if g.AssistPlayerID == nil {
+		g.AssistPlayerID = current.RelatedPlayerID
+	} else if *g.AssistPlayerID == 0 {
+		g.AssistPlayerID = nil
+	}
+	changes, err := goalEvent(g)
+	if err != nil {
+		return err
 	}
+	changes.Note = current.Note
 
-	validMinute, _ := regexp.MatchString(`^([0-9]|[1-8][0-9]|90)$|(^(45|90)\+[0-9]+$)`, g.Minute)
-	if !validMinute {
-		return apperror.NewValidationError(
-			"format menit tidak valid. Gunakan angka 0–90 atau hanya 45+X / 90+X (contoh: 45+2, 90+3)",
-		)
+	if _, err := s.eventSvc.Update(current.MatchID, current.ID, &changes, userID, reason); err != nil {
+		return err
 	}
 
-	invalidStatuses := map[string]bool{
-		"DIJADWALKAN": true,
-		"SELESAI":     true,
-		"DIBATALKAN":  true,
+	g.ID = goalID
+	return nil
+}
+
+func (s *goalService) DeleteGoal(goalID uint, userID uint, reason string) error {
+	current, err := s.eventSvc.GoalEvent(goalID)
+	if err != nil {
+		return err
 	}
+	return s.eventSvc.Delete(current.MatchID, current.ID, userID, reason)
+}
 
-	if invalidStatuses[match.Status] {
-		return apperror.NewValidationError(
-			"tidak dapat menambahkan gol karena status pertandingan: " + match.Status,
-		)
+func goalEvent(g *models.Goal) (models.MatchEvent, error) {
+	if g.ScorerPlayerID == 0 {
+		return models.MatchEvent{}, apperror.NewValidationError("pencetak gol wajib diisi")
+	}
+	if g.MatchID == 0 {
+		return models.MatchEvent{}, apperror.NewValidationError("match_id wajib diisi")
+	}
+	if g.TeamID == 0 {
+		return models.MatchEvent{}, apperror.NewValidationError("team_id wajib diisi")
 	}
 
-	if err := s.repo.AddGoal(g); err != nil {
-		return apperror.NewInternalError("gagal menambahkan gol")
+	if g.Type == "" {
+		g.Type = "BIASA"
+	}
+	eventType, ok := eventByGoalType[g.Type]
+	if !ok {
+		return models.MatchEvent{}, apperror.NewValidationError("tipe gol harus BIASA, PENALTI, atau BUNUH_DIRI")
 	}
 
-	return nil
+	return models.MatchEvent{
+		MatchID:         g.MatchID,
+		TeamID:          g.TeamID,
+		PlayerID:        g.ScorerPlayerID,
+		RelatedPlayerID: g.AssistPlayerID,
+		Type:            eventType,
+		Minute:          g.Minute,
+	}, nil
 }
 
 func (s *goalService) GetGoals(matchID uint) ([]models.Goal, error) {
@@ -83,8 +118,8 @@ func (s *goalService) GetGoals(matchID uint) ([]models.Goal, error) {
 	return goals, nil
 }
 
-func (s *goalService) TopScorers(limit int) ([]dto.TopScorerDTO, error) {
-	list, err := s.repo.TopScorers(limit)
+func (s *goalService) TopScorers(seasonID uint, limit int) ([]dto.TopScorerDTO, error) {
+	list, err := s.repo.TopScorers(seasonID, limit)
 	if err != nil {
 		return nil, apperror.NewInternalError("gagal mengambil top scorer")
 	}
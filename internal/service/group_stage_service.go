@@ -0,0 +1,475 @@
+package service
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"time"
+
+	"football-backend/internal/dto"
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+type GroupInput struct {
+	Name    string
+	TeamIDs []uint
+}
+
+type GroupStageOptions struct {
+	SeasonID           uint
+	Name               string
+	Groups             []GroupInput
+	QualifiersPerGroup int
+	BestThirdPlaced    int
+	DrawRule           string
+	TwoLegged          bool
+	AwayGoalsRule      bool
+	ExtraTime          bool
+
+	FixtureStart *time.Time
+	IntervalDays int
+	DoubleRound  bool
+}
+
+type KnockoutDrawOptions struct {
+	StartDate         time.Time
+	RoundIntervalDays int
+	LegIntervalDays   int
+}
+
+type GroupStageService interface {
+	Create(opt GroupStageOptions) (*models.GroupStage, error)
+	GetByID(id uint) (*models.GroupStage, error)
+	GetBySeason(seasonID uint) ([]models.GroupStage, error)
+	Standings(id uint) ([]dto.GroupTableDTO, error)
+	DrawKnockout(id uint, opt KnockoutDrawOptions) (*models.Bracket, error)
+}
+
+type groupStageService struct {
+	repo         repository.GroupStageRepository
+	matchRepo    repository.MatchRepository
+	teamRepo     repository.TeamRepository
+	seasonRepo   repository.SeasonRepository
+	ruleRepo     repository.StandingRuleRepository
+	sanctionRepo repository.TeamSanctionRepository
+	eventRepo    repository.MatchEventRepository
+	bracketSvc   BracketService
+	uow          repository.UnitOfWork
+	venueWindow  time.Duration
+}
+
+func NewGroupStageService(
+	r repository.GroupStageRepository,
+	mr repository.MatchRepository,
+	tr repository.TeamRepository,
+	sr repository.SeasonRepository,
+	rr repository.StandingRuleRepository,
+	tsr repository.TeamSanctionRepository,
+	er repository.MatchEventRepository,
+	bs BracketService,
+	uow repository.UnitOfWork,
+	venueWindow time.Duration,
+) GroupStageService {
+	return &groupStageService{
+		repo:         r,
+		matchRepo:    mr,
+		teamRepo:     tr,
+		seasonRepo:   sr,
+		ruleRepo:     rr,
+		sanctionRepo: tsr,
+		eventRepo:    er,
+		bracketSvc:   bs,
+		uow:          uow,
+		venueWindow:  venueWindow,
+	}
+}
+
+type groupQualifier struct {
+	GroupID uint
+	Row     dto.StandingDTO
+}
+
+func validateDrawRule(rule string) bool {
+	valid := map[string]bool{
+		"JUARA_VS_RUNNER_UP": true,
+		"UNGGULAN":           true,
+		"ACAK":               true,
+	}
+	return valid[rule]
+}
+
+func (s *groupStageService) Create(opt GroupStageOptions) (*models.GroupStage, error) {
+	if opt.Name == "" {
+		return nil, apperror.NewValidationError("nama fase grup wajib diisi")
+	}
+	if len(opt.Groups) == 0 {
+		return nil, apperror.NewValidationError("minimal 1 grup wajib diisi")
+	}
+
+	opt.DrawRule = strings.ToUpper(opt.DrawRule)
+	if !validateDrawRule(opt.DrawRule) {
+		return nil, apperror.NewValidationError("draw_rule tidak valid (JUARA_VS_RUNNER_UP, UNGGULAN, ACAK)")
+	}
+
+	if _, err := s.seasonRepo.GetByID(opt.SeasonID); err != nil {
+		return nil, apperror.NewNotFoundError("musim tidak ditemukan")
+	}
+
+	minSize := 0
+	names := map[string]bool{}
+	seen := map[uint]bool{}
+	for _, g := range opt.Groups {
+		if g.Name == "" {
+			return nil, apperror.NewValidationError("nama grup wajib diisi")
+		}
+		if names[g.Name] {
+			return nil, apperror.NewValidationError("nama grup " + g.Name + " duplikat")
+		}
+		names[g.Name] = true
+
+		if len(g.TeamIDs) < 2 {
+			return nil, apperror.NewValidationError("grup " + g.Name + " minimal berisi 2 tim")
+		}
+		if minSize == 0 || len(g.TeamIDs) < minSize {
+			minSize = len(g.TeamIDs)
+		}
+
+		for _, id := range g.TeamIDs {
+			if seen[id] {
+				return nil, apperror.NewValidationError(fmt.Sprintf("tim %d terdaftar di lebih dari satu grup", id))
+			}
+			seen[id] = true
+			if _, err := s.teamRepo.GetByID(id); err != nil {
+				return nil, apperror.NewNotFoundError(fmt.Sprintf("tim %d tidak ditemukan", id))
+			}
+		}
+	}
+
+	if opt.QualifiersPerGroup < 1 || opt.QualifiersPerGroup > minSize {
+		return nil, apperror.NewValidationError("jumlah tim lolos per grup tidak valid")
+	}
+	if opt.BestThirdPlaced < 0 || opt.BestThirdPlaced > len(opt.Groups) {
+		return nil, apperror.NewValidationError("jumlah peringkat ketiga terbaik tidak valid")
+	}
+	if opt.BestThirdPlaced > 0 && opt.QualifiersPerGroup >= minSize {
+		return nil, apperror.NewValidationError("grup tidak memiliki peringkat berikutnya untuk dipilih sebagai tim terbaik")
+	}
+	if opt.QualifiersPerGroup*len(opt.Groups)+opt.BestThirdPlaced < 2 {
+		return nil, apperror.NewValidationError("minimal 2 tim harus lolos ke fase knockout")
+	}
+
+	stage := &models.GroupStage{
+		SeasonID:           opt.SeasonID,
+		Name:               opt.Name,
+		QualifiersPerGroup: opt.QualifiersPerGroup,
+		BestThirdPlaced:    opt.BestThirdPlaced,
+		DrawRule:           opt.DrawRule,
+		TwoLegged:          opt.TwoLegged,
+		AwayGoalsRule:      opt.AwayGoalsRule,
+		ExtraTime:          opt.ExtraTime,
+	}
+	err := s.uow.Do(func(r repository.Repositories) error {
+		if err := r.GroupStages.Create(stage); err != nil {
+			return apperror.NewInternalError("gagal membuat fase grup")
+		}
+
+		matchSvc := matchServiceTx(r, s.venueWindow)
+		for _, g := range opt.Groups {
+			teams := make([]models.Team, 0, len(g.TeamIDs))
+			for _, id := range g.TeamIDs {
+				teams = append(teams, models.Team{ID: id})
+			}
+
+			group := &models.TournamentGroup{
+				GroupStageID: stage.ID,
+				Name:         g.Name,
+				Teams:        teams,
+			}
+			if err := r.GroupStages.CreateGroup(group); err != nil {
+				return apperror.NewInternalError("gagal membuat grup " + g.Name)
+			}
+
+			if opt.FixtureStart != nil {
+				groupID := group.ID
+				_, err := matchSvc.generateFixtures(FixtureOptions{
+					SeasonID:     opt.SeasonID,
+					GroupID:      &groupID,
+					TeamIDs:      g.TeamIDs,
+					StartDate:    *opt.FixtureStart,
+					IntervalDays: opt.IntervalDays,
+					DoubleRound:  opt.DoubleRound,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(stage.ID)
+}
+
+func (s *groupStageService) GetByID(id uint) (*models.GroupStage, error) {
+	gs, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("fase grup tidak ditemukan")
+	}
+	return gs, nil
+}
+
+func (s *groupStageService) GetBySeason(seasonID uint) ([]models.GroupStage, error) {
+	list, err := s.repo.GetBySeason(seasonID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data fase grup")
+	}
+	return list, nil
+}
+
+func (s *groupStageService) groupTables(stage *models.GroupStage) ([]dto.GroupTableDTO, error) {
+	cfg, err := loadStandingConfig(stage.SeasonID, s.seasonRepo, s.ruleRepo, s.sanctionRepo, s.eventRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := []dto.GroupTableDTO{}
+
+	for _, g := range stage.Groups {
+		matches, err := s.matchRepo.GetFinishedMatchesByGroup(g.ID)
+		if err != nil {
+			return nil, apperror.NewInternalError("gagal mengambil pertandingan grup " + g.Name)
+		}
+
+		rows := []dto.GroupStandingRowDTO{}
+		for _, row := range buildStanding(g.Teams, matches, cfg) {
+			rows = append(rows, dto.GroupStandingRowDTO{StandingDTO: row})
+		}
+
+		tables = append(tables, dto.GroupTableDTO{
+			GroupID:   g.ID,
+			GroupName: g.Name,
+			Table:     rows,
+		})
+	}
+
+	return tables, nil
+}
+
+func compareStandingRows(a, b dto.StandingDTO) bool {
+	if a.Points != b.Points {
+		return a.Points > b.Points
+	}
+	if a.GoalDifference != b.GoalDifference {
+		return a.GoalDifference > b.GoalDifference
+	}
+	return a.GoalsFor > b.GoalsFor
+}
+
+func qualifiers(stage *models.GroupStage, tables []dto.GroupTableDTO) []groupQualifier {
+	result := []groupQualifier{}
+
+	for pos := 1; pos <= stage.QualifiersPerGroup; pos++ {
+		tier := []groupQualifier{}
+		for _, t := range tables {
+			if pos <= len(t.Table) {
+				tier = append(tier, groupQualifier{GroupID: t.GroupID, Row: t.Table[pos-1].StandingDTO})
+			}
+		}
+		sort.SliceStable(tier, func(i, j int) bool { return compareStandingRows(tier[i].Row, tier[j].Row) })
+		result = append(result, tier...)
+	}
+
+	if stage.BestThirdPlaced > 0 {
+		pos := stage.QualifiersPerGroup + 1
+		thirds := []groupQualifier{}
+		for _, t := range tables {
+			if pos <= len(t.Table) {
+				thirds = append(thirds, groupQualifier{GroupID: t.GroupID, Row: t.Table[pos-1].StandingDTO})
+			}
+		}
+		sort.SliceStable(thirds, func(i, j int) bool { return compareStandingRows(thirds[i].Row, thirds[j].Row) })
+		if len(thirds) > stage.BestThirdPlaced {
+			thirds = thirds[:stage.BestThirdPlaced]
+		}
+		result = append(result, thirds...)
+	}
+
+	return result
+}
+
+func (s *groupStageService) Standings(id uint) ([]dto.GroupTableDTO, error) {
+	stage, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := s.groupTables(stage)
+	if err != nil {
+		return nil, err
+	}
+
+	qualified := map[uint]bool{}
+	for _, q := range qualifiers(stage, tables) {
+		qualified[q.Row.TeamID] = true
+	}
+
+	for i := range tables {
+		for j := range tables[i].Table {
+			row := &tables[i].Table[j]
+			switch {
+			case row.Position <= stage.QualifiersPerGroup:
+				row.Qualification = "LOLOS"
+			case qualified[row.TeamID]:
+				row.Qualification = "PERINGKAT_TERBAIK"
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+func drawAvoidingSameGroup(top, pot []groupQualifier) []groupQualifier {
+	shuffled := append([]groupQualifier{}, pot...)
+	rand.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	used := make([]bool, len(shuffled))
+	result := make([]groupQualifier, len(top))
+
+	var assign func(i int) bool
+	assign = func(i int) bool {
+		if i == len(top) {
+			return true
+		}
+		for j := range shuffled {
+			if used[j] || shuffled[j].GroupID == top[i].GroupID {
+				continue
+			}
+			used[j] = true
+			result[i] = shuffled[j]
+			if assign(i + 1) {
+				return true
+			}
+			used[j] = false
+		}
+		return false
+	}
+
+	if !assign(0) {
+		return shuffled
+	}
+	return result
+}
+
+func (s *groupStageService) DrawKnockout(id uint, opt KnockoutDrawOptions) (*models.Bracket, error) {
+	var bracketID uint
+	err := s.uow.Do(func(r repository.Repositories) error {
+		locked, err := r.GroupStages.GetForUpdate(id)
+		if err != nil {
+			return apperror.NewNotFoundError("fase grup tidak ditemukan")
+		}
+		if locked.BracketID != nil {
+			return apperror.NewConflictError("undian fase knockout sudah dilakukan")
+		}
+
+		stage, err := r.GroupStages.GetByID(id)
+		if err != nil {
+			return apperror.NewNotFoundError("fase grup tidak ditemukan")
+		}
+
+		unfinished, err := r.GroupStages.CountUnfinishedMatches(stage.ID)
+		if err != nil {
+			return apperror.NewInternalError("gagal memeriksa pertandingan grup")
+		}
+		if unfinished > 0 {
+			return apperror.NewValidationError(fmt.Sprintf("masih ada %d pertandingan grup yang belum selesai", unfinished))
+		}
+
+		tables, err := s.groupTables(stage)
+		if err != nil {
+			return err
+		}
+
+		bracketOpt, err := knockoutOptions(stage, tables, opt)
+		if err != nil {
+			return err
+		}
+
+		bracketID, err = bracketServiceTx(r, s.venueWindow).create(bracketOpt)
+		if err != nil {
+			return err
+		}
+
+		stage.BracketID = &bracketID
+		if err := r.GroupStages.Update(stage); err != nil {
+			return apperror.NewInternalError("gagal menyimpan bracket fase grup")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.bracketSvc.GetByID(bracketID)
+}
+
+func knockoutOptions(stage *models.GroupStage, tables []dto.GroupTableDTO, opt KnockoutDrawOptions) (BracketOptions, error) {
+	qualified := qualifiers(stage, tables)
+	if len(qualified) < 2 {
+		return BracketOptions{}, apperror.NewValidationError("jumlah tim lolos kurang dari 2")
+	}
+
+	teamIDs := make([]uint, 0, len(qualified))
+	for _, q := range qualified {
+		teamIDs = append(teamIDs, q.Row.TeamID)
+	}
+
+	bracketOpt := BracketOptions{
+		SeasonID:          stage.SeasonID,
+		Name:              stage.Name + " - Knockout",
+		TeamIDs:           teamIDs,
+		TwoLegged:         stage.TwoLegged,
+		AwayGoalsRule:     stage.AwayGoalsRule,
+		ExtraTime:         stage.ExtraTime,
+		StartDate:         opt.StartDate,
+		RoundIntervalDays: opt.RoundIntervalDays,
+		LegIntervalDays:   opt.LegIntervalDays,
+	}
+
+	switch stage.DrawRule {
+	case "ACAK":
+		bracketOpt.Seeded = false
+	case "UNGGULAN":
+		bracketOpt.Seeded = true
+	default:
+		bracketOpt.Seeded = true
+
+		top := []groupQualifier{}
+		pot := []groupQualifier{}
+		for _, q := range qualified {
+			if q.Row.Position == 1 {
+				top = append(top, q)
+			} else {
+				pot = append(pot, q)
+			}
+		}
+
+		if len(top) == len(pot) && nextPowerOfTwo(len(qualified)) == len(qualified) {
+			opponents := drawAvoidingSameGroup(top, pot)
+			for i := range top {
+				if stage.TwoLegged {
+					bracketOpt.Pairs = append(bracketOpt.Pairs, [2]uint{opponents[i].Row.TeamID, top[i].Row.TeamID})
+				} else {
+					bracketOpt.Pairs = append(bracketOpt.Pairs, [2]uint{top[i].Row.TeamID, opponents[i].Row.TeamID})
+				}
+			}
+		}
+	}
+
+	return bracketOpt, nil
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"football-backend/internal/dto"
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+const (
+	RevisionUpdate = "UBAH"
+	RevisionDelete = "HAPUS"
+)
+
+type eventSnapshot struct {
+	Type            string `json:"type"`
+	TeamID          uint   `json:"team_id"`
+	PlayerID        uint   `json:"player_id"`
+	RelatedPlayerID *uint  `json:"related_player_id"`
+	Minute          string `json:"minute"`
+	Note            string `json:"note,omitempty"`
+}
+
+func snapshotEvent(e *models.MatchEvent) string {
+	b, _ := json.Marshal(eventSnapshot{
+		Type:            e.Type,
+		TeamID:          e.TeamID,
+		PlayerID:        e.PlayerID,
+		RelatedPlayerID: e.RelatedPlayerID,
+		Minute:          e.Minute,
+		Note:            e.Note,
+	})
+	return string(b)
+}
+
+func (s *matchEventService) Update(matchID, eventID uint, changes *models.MatchEvent, userID uint, reason string) (*models.MatchEvent, error) {
+	var event *models.MatchEvent
+	err := s.uow.Do(func(r repository.Repositories) error {
+		var err error
+		var recount []uint
+		event, recount, err = matchEventServiceTx(r).update(matchID, eventID, changes, userID, reason)
+		if err != nil {
+			return err
+		}
+		return reviseResult(r, matchID, s.venueWindow, recount)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishCorrection(matchID)
+	return event, nil
+}
+
+func (s *matchEventService) Delete(matchID, eventID uint, userID uint, reason string) error {
+	err := s.uow.Do(func(r repository.Repositories) error {
+		recount, err := matchEventServiceTx(r).delete(matchID, eventID, userID, reason)
+		if err != nil {
+			return err
+		}
+		return reviseResult(r, matchID, s.venueWindow, recount)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishCorrection(matchID)
+	return nil
+}
+
+func (s *matchEventService) GoalEvent(goalID uint) (*models.MatchEvent, error) {
+	var event *models.MatchEvent
+	err := s.uow.Do(func(r repository.Repositories) error {
+		var err error
+		event, err = matchEventServiceTx(r).goalEvent(goalID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (s *matchEventService) goalEvent(goalID uint) (*models.MatchEvent, error) {
+	goal, err := s.goalRepo.GetByID(goalID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("gol tidak ditemukan")
+	}
+
+	if event, err := s.repo.GetByGoalID(goalID); err == nil {
+		return event, nil
+	}
+
+	eventType, ok := eventByGoalType[goal.Type]
+	if !ok {
+		eventType = EventGoal
+	}
+	event := &models.MatchEvent{
+		MatchID:         goal.MatchID,
+		TeamID:          goal.TeamID,
+		PlayerID:        goal.ScorerPlayerID,
+		RelatedPlayerID: goal.AssistPlayerID,
+		GoalID:          &goal.ID,
+		Type:            eventType,
+		Minute:          goal.Minute,
+	}
+	if err := s.repo.Create(event); err != nil {
+		return nil, apperror.NewInternalError("gagal menyimpan event pertandingan")
+	}
+	return event, nil
+}
+
+func (s *matchEventService) Revisions(matchID uint) ([]models.MatchEventRevision, error) {
+	if _, err := s.matchRepo.GetByID(matchID); err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	revisions, err := s.revisionRepo.GetByMatch(matchID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil riwayat koreksi")
+	}
+	return revisions, nil
+}
+
+func (s *matchEventService) correctable(matchID, eventID uint, reason string) (*models.MatchEvent, *models.Match, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, nil, apperror.NewValidationError("alasan koreksi wajib diisi")
+	}
+
+	event, err := s.repo.GetByID(eventID)
+	if err != nil || event.MatchID != matchID {
+		return nil, nil, apperror.NewNotFoundError("event pertandingan tidak ditemukan")
+	}
+
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return nil, nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+	if !isMatchInPlay(match.Status) && match.Status != MatchFinished {
+		return nil, nil, apperror.NewValidationError(
+			"event tidak dapat dikoreksi karena status pertandingan: " + match.Status,
+		)
+	}
+	return event, match, nil
+}
+
+func (s *matchEventService) update(matchID, eventID uint, changes *models.MatchEvent, userID uint, reason string) (*models.MatchEvent, []uint, error) {
+	event, match, err := s.correctable(matchID, eventID, reason)
+	if err != nil {
+		return nil, nil, err
+	}
+	before := snapshotEvent(event)
+	recount := yellowCardHolders(event)
+
+	edited := *event
+	if changes.Type != "" && changes.Type != event.Type {
+		_, wasGoal := goalTypeByEvent[event.Type]
+		_, isGoal := goalTypeByEvent[changes.Type]
+		if !wasGoal || !isGoal {
+			return nil, nil, apperror.NewValidationError("tipe event hanya dapat diubah antar tipe gol; hapus lalu catat ulang untuk tipe lain")
+		}
+		edited.Type = changes.Type
+	}
+	edited.TeamID = changes.TeamID
+	edited.PlayerID = changes.PlayerID
+	edited.RelatedPlayerID = changes.RelatedPlayerID
+	edited.Minute = changes.Minute
+	edited.Note = changes.Note
+
+	events, err := s.repo.GetByMatch(matchID)
+	if err != nil {
+		return nil, nil, apperror.NewInternalError("gagal mengambil event pertandingan")
+	}
+	others := make([]models.MatchEvent, 0, len(events))
+	prior := make([]models.MatchEvent, 0, len(events))
+	for _, other := range events {
+		if other.ID == event.ID {
+			continue
+		}
+		others = append(others, other)
+		if eventBefore(other, edited) {
+			prior = append(prior, other)
+		}
+	}
+
+	if err := s.validate(&edited, match, prior); err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkSequence(matchID, append(others, edited)); err != nil {
+		return nil, nil, err
+	}
+
+	event.Type = edited.Type
+	event.TeamID = edited.TeamID
+	event.PlayerID = edited.PlayerID
+	event.RelatedPlayerID = edited.RelatedPlayerID
+	event.Minute = edited.Minute
+	event.Note = edited.Note
+
+	if event.GoalID != nil {
+		goal, err := s.goalRepo.GetByID(*event.GoalID)
+		if err != nil {
+			return nil, nil, apperror.NewNotFoundError("gol tidak ditemukan")
+		}
+		goal.TeamID = event.TeamID
+		goal.ScorerPlayerID = event.PlayerID
+		goal.AssistPlayerID = event.RelatedPlayerID
+		goal.Type = goalTypeByEvent[event.Type]
+		goal.Minute = event.Minute
+		if err := s.goalRepo.Update(goal); err != nil {
+			return nil, nil, apperror.NewInternalError("gagal memperbarui gol")
+		}
+	}
+
+	if err := s.repo.Update(event); err != nil {
+		return nil, nil, apperror.NewInternalError("gagal memperbarui event pertandingan")
+	}
+
+	rev := models.MatchEventRevision{
+		MatchID:     matchID,
+		EventID:     &event.ID,
+		GoalID:      event.GoalID,
+		Action:      RevisionUpdate,
+		Before:      before,
+		After:       snapshotEvent(event),
+		Reason:      reason,
+		ChangedByID: userID,
+	}
+	if err := s.revisionRepo.Create(&rev); err != nil {
+		return nil, nil, apperror.NewInternalError("gagal menyimpan riwayat koreksi")
+	}
+	return event, recount, nil
+}
+
+func (s *matchEventService) delete(matchID, eventID uint, userID uint, reason string) ([]uint, error) {
+	event, _, err := s.correctable(matchID, eventID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.GetByMatch(matchID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil event pertandingan")
+	}
+	for _, other := range events {
+		if other.ID == event.ID {
+			continue
+		}
+		if event.Type == EventYellowCard && other.Type == EventSecondYellow && other.PlayerID == event.PlayerID {
+			return nil, apperror.NewValidationError("hapus kartu kuning kedua pemain ini terlebih dahulu")
+		}
+		if event.Type == EventSubstitution && other.ID > event.ID &&
+			(other.PlayerID == event.PlayerID || (other.RelatedPlayerID != nil && *other.RelatedPlayerID == event.PlayerID)) {
+			return nil, apperror.NewValidationError("pemain pengganti sudah memiliki event lain; hapus event tersebut terlebih dahulu")
+		}
+	}
+
+	if event.GoalID != nil {
+		goal, err := s.goalRepo.GetByID(*event.GoalID)
+		if err == nil {
+			if err := s.goalRepo.Delete(goal); err != nil {
+				return nil, apperror.NewInternalError("gagal menghapus gol")
+			}
+		}
+	}
+
+	if err := s.repo.Delete(event); err != nil {
+		return nil, apperror.NewInternalError("gagal menghapus event pertandingan")
+	}
+
+	rev := models.MatchEventRevision{
+		MatchID:     matchID,
+		EventID:     &event.ID,
+		GoalID:      event.GoalID,
+		Action:      RevisionDelete,
+		Before:      snapshotEvent(event),
+		Reason:      reason,
+		ChangedByID: userID,
+	}
+	if err := s.revisionRepo.Create(&rev); err != nil {
+		return nil, apperror.NewInternalError("gagal menyimpan riwayat koreksi")
+	}
+	return yellowCardHolders(event), nil
+}
+
+func yellowCardHolders(e *models.MatchEvent) []uint {
+	if e.Type != EventYellowCard {
+		return nil
+	}
+	return []uint{e.PlayerID}
+}
+
+func eventBefore(a, b models.MatchEvent) bool {
+	if minuteBase(a.Minute) != minuteBase(b.Minute) {
+		return minuteBase(a.Minute) < minuteBase(b.Minute)
+	}
+	if minuteAdded(a.Minute) != minuteAdded(b.Minute) {
+		return minuteAdded(a.Minute) < minuteAdded(b.Minute)
+	}
+	return a.ID < b.ID
+}
+
+func (s *matchEventService) checkSequence(matchID uint, events []models.MatchEvent) error {
+	sorted := append([]models.MatchEvent(nil), events...)
+	sort.SliceStable(sorted, func(i, j int) bool { return eventBefore(sorted[i], sorted[j]) })
+
+	lineups := map[uint]*models.MatchLineup{}
+	for i := range sorted {
+		e := &sorted[i]
+		lineup, ok := lineups[e.TeamID]
+		if !ok {
+			lineup, _ = s.lineupRepo.GetByMatchTeam(matchID, e.TeamID)
+			lineups[e.TeamID] = lineup
+		}
+
+		if err := checkEventState(e, newMatchPlayerState(sorted[:i]), lineup); err != nil {
+			return apperror.NewValidationError("event " + e.Type + " menit " + e.Minute + " menjadi tidak valid: " + err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *matchEventService) publishCorrection(matchID uint) {
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return
+	}
+	s.publisher.Publish("KOREKSI", matchID, dto.ToLiveMatchDTO(match, nil))
+}
@@ -0,0 +1,504 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"football-backend/internal/dto"
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/live"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+const (
+	EventGoal          = "GOL"
+	EventPenaltyGoal   = "GOL_PENALTI"
+	EventOwnGoal       = "GOL_BUNUH_DIRI"
+	EventPenaltyMissed = "PENALTI_GAGAL"
+	EventYellowCard    = "KARTU_KUNING"
+	EventSecondYellow  = "KARTU_KUNING_KEDUA"
+	EventRedCard       = "KARTU_MERAH"
+	EventSubstitution  = "PERGANTIAN"
+)
+
+const maxSubstitutions = 5
+
+var goalTypeByEvent = map[string]string{
+	EventGoal:        "BIASA",
+	EventPenaltyGoal: "PENALTI",
+	EventOwnGoal:     "BUNUH_DIRI",
+}
+
+var eventByGoalType = map[string]string{
+	"BIASA":      EventGoal,
+	"PENALTI":    EventPenaltyGoal,
+	"BUNUH_DIRI": EventOwnGoal,
+}
+
+var validEventTypes = map[string]bool{
+	EventGoal:          true,
+	EventPenaltyGoal:   true,
+	EventOwnGoal:       true,
+	EventPenaltyMissed: true,
+	EventYellowCard:    true,
+	EventSecondYellow:  true,
+	EventRedCard:       true,
+	EventSubstitution:  true,
+}
+
+var fairPlayCardPoints = map[string]int{
+	EventYellowCard:   1,
+	EventSecondYellow: 2,
+	EventRedCard:      4,
+}
+
+type MatchEventService interface {
+	Record(e *models.MatchEvent) error
+	Update(matchID, eventID uint, changes *models.MatchEvent, userID uint, reason string) (*models.MatchEvent, error)
+	Delete(matchID, eventID uint, userID uint, reason string) error
+	GoalEvent(goalID uint) (*models.MatchEvent, error)
+	Revisions(matchID uint) ([]models.MatchEventRevision, error)
+	Timeline(matchID uint) (*dto.MatchTimelineDTO, error)
+}
+
+type matchEventService struct {
+	repo         repository.MatchEventRepository
+	matchRepo    repository.MatchRepository
+	goalRepo     repository.GoalRepository
+	playerRepo   repository.PlayerRepository
+	lineupRepo   repository.MatchLineupRepository
+	revisionRepo repository.MatchEventRevisionRepository
+	suspensions  repository.PlayerSuspensionRepository
+	publisher    live.Publisher
+	uow          repository.UnitOfWork
+	venueWindow  time.Duration
+}
+
+func NewMatchEventService(
+	r repository.MatchEventRepository,
+	mr repository.MatchRepository,
+	gr repository.GoalRepository,
+	pr repository.PlayerRepository,
+	lr repository.MatchLineupRepository,
+	rv repository.MatchEventRevisionRepository,
+	sr repository.PlayerSuspensionRepository,
+	p live.Publisher,
+	uow repository.UnitOfWork,
+	venueWindow time.Duration,
+) MatchEventService {
+	return &matchEventService{
+		repo:         r,
+		matchRepo:    mr,
+		goalRepo:     gr,
+		playerRepo:   pr,
+		lineupRepo:   lr,
+		revisionRepo: rv,
+		suspensions:  sr,
+		publisher:    p,
+		uow:          uow,
+		venueWindow:  venueWindow,
+	}
+}
+
+func matchEventServiceTx(r repository.Repositories) *matchEventService {
+	return &matchEventService{
+		repo:         r.MatchEvents,
+		matchRepo:    r.Matches,
+		goalRepo:     r.Goals,
+		playerRepo:   r.Players,
+		lineupRepo:   r.MatchLineups,
+		revisionRepo: r.EventRevisions,
+		suspensions:  r.Suspensions,
+	}
+}
+
+type matchPlayerState struct {
+	yellows   map[uint]int
+	sentOff   map[uint]bool
+	subbedOn  map[uint]bool
+	subbedOff map[uint]bool
+	subs      map[uint]int
+}
+
+func newMatchPlayerState(events []models.MatchEvent) matchPlayerState {
+	st := matchPlayerState{
+		yellows:   map[uint]int{},
+		sentOff:   map[uint]bool{},
+		subbedOn:  map[uint]bool{},
+		subbedOff: map[uint]bool{},
+		subs:      map[uint]int{},
+	}
+
+	for _, e := range events {
+		switch e.Type {
+		case EventYellowCard:
+			st.yellows[e.PlayerID]++
+		case EventSecondYellow:
+			st.yellows[e.PlayerID]++
+			st.sentOff[e.PlayerID] = true
+		case EventRedCard:
+			st.sentOff[e.PlayerID] = true
+		case EventSubstitution:
+			st.subbedOn[e.PlayerID] = true
+			if e.RelatedPlayerID != nil {
+				st.subbedOff[*e.RelatedPlayerID] = true
+			}
+			st.subs[e.TeamID]++
+		}
+	}
+
+	return st
+}
+
+func (st matchPlayerState) available(playerID uint) error {
+	if st.sentOff[playerID] {
+		return apperror.NewValidationError("pemain sudah dikeluarkan dari lapangan")
+	}
+	if st.subbedOff[playerID] {
+		return apperror.NewValidationError("pemain sudah diganti")
+	}
+	return nil
+}
+
+func checkLineup(lineup *models.MatchLineup, state matchPlayerState, e *models.MatchEvent) error {
+	starters := map[uint]bool{}
+	bench := map[uint]bool{}
+	for _, lp := range lineup.Players {
+		if lp.Starter {
+			starters[lp.PlayerID] = true
+		} else {
+			bench[lp.PlayerID] = true
+		}
+	}
+
+	onPitch := func(playerID uint) error {
+		if !starters[playerID] && !bench[playerID] {
+			return apperror.NewValidationError("pemain tidak terdaftar dalam susunan pemain")
+		}
+		if !starters[playerID] && !state.subbedOn[playerID] {
+			return apperror.NewValidationError("pemain tidak sedang berada di lapangan")
+		}
+		return nil
+	}
+
+	switch e.Type {
+	case EventYellowCard, EventSecondYellow, EventRedCard:
+		if !starters[e.PlayerID] && !bench[e.PlayerID] {
+			return apperror.NewValidationError("pemain tidak terdaftar dalam susunan pemain")
+		}
+	case EventSubstitution:
+		if !bench[e.PlayerID] {
+			return apperror.NewValidationError("pemain masuk harus terdaftar sebagai cadangan")
+		}
+		if e.RelatedPlayerID != nil {
+			return onPitch(*e.RelatedPlayerID)
+		}
+	default:
+		if err := onPitch(e.PlayerID); err != nil {
+			return err
+		}
+		if e.RelatedPlayerID != nil {
+			return onPitch(*e.RelatedPlayerID)
+		}
+	}
+	return nil
+}
+
+func validateMinute(match *models.Match, minute string) error {
+	if match.TieID != nil {
+		valid, _ := regexp.MatchString(`^([0-9]|[1-9][0-9]|1[01][0-9]|120)$|(^(45|90|105|120)\+[0-9]+$)`, minute)
+		if !valid {
+			return apperror.NewValidationError(
+				"format menit tidak valid. Gunakan angka 0–120 atau 45+X / 90+X / 105+X / 120+X (contoh: 105+1)",
+			)
+		}
+		return nil
+	}
+
+	valid, _ := regexp.MatchString(`^([0-9]|[1-8][0-9]|90)$|(^(45|90)\+[0-9]+$)`, minute)
+	if !valid {
+		return apperror.NewValidationError(
+			"format menit tidak valid. Gunakan angka 0–90 atau hanya 45+X / 90+X (contoh: 45+2, 90+3)",
+		)
+	}
+	return nil
+}
+
+func minuteAdded(minute string) int {
+	parts := strings.Split(minute, "+")
+	if len(parts) < 2 {
+		return 0
+	}
+	added, _ := strconv.Atoi(parts[1])
+	return added
+}
+
+func (s *matchEventService) Record(e *models.MatchEvent) error {
+	err := s.uow.Do(func(r repository.Repositories) error {
+		return matchEventServiceTx(r).record(e)
+	})
+	if err != nil {
+		return err
+	}
+
+	publishMatchEvent(s.publisher, s.matchRepo, s.repo, e)
+	return nil
+}
+
+func (s *matchEventService) record(e *models.MatchEvent) error {
+	if !validEventTypes[e.Type] {
+		return apperror.NewValidationError("tipe event tidak valid")
+	}
+	if e.MatchID == 0 || e.TeamID == 0 || e.PlayerID == 0 {
+		return apperror.NewValidationError("match_id, team_id dan player_id wajib diisi")
+	}
+
+	match, err := s.matchRepo.GetByID(e.MatchID)
+	if err != nil {
+		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	if !isMatchInPlay(match.Status) {
+		return apperror.NewValidationError(
+			"tidak dapat menambahkan event karena status pertandingan: " + match.Status,
+		)
+	}
+	if match.Status == MatchHalfTime && fairPlayCardPoints[e.Type] == 0 && e.Type != EventSubstitution {
+		return apperror.NewValidationError("saat istirahat hanya kartu dan pergantian pemain yang dapat dicatat")
+	}
+
+	events, err := s.repo.GetByMatch(e.MatchID)
+	if err != nil {
+		return apperror.NewInternalError("gagal mengambil event pertandingan")
+	}
+
+	if e.Type == EventYellowCard && newMatchPlayerState(events).yellows[e.PlayerID] >= 1 {
+		e.Type = EventSecondYellow
+	}
+	if err := s.validate(e, match, events); err != nil {
+		return err
+	}
+
+	if goalType, ok := goalTypeByEvent[e.Type]; ok {
+		goal := models.Goal{
+			MatchID:        e.MatchID,
+			TeamID:         e.TeamID,
+			ScorerPlayerID: e.PlayerID,
+			AssistPlayerID: e.RelatedPlayerID,
+			Type:           goalType,
+			Minute:         e.Minute,
+		}
+		if err := s.goalRepo.AddGoal(&goal); err != nil {
+			return apperror.NewInternalError("gagal menambahkan gol")
+		}
+		e.GoalID = &goal.ID
+	}
+
+	if err := s.repo.Create(e); err != nil {
+		return apperror.NewInternalError("gagal menyimpan event pertandingan")
+	}
+	return nil
+}
+
+func (s *matchEventService) validate(e *models.MatchEvent, match *models.Match, prior []models.MatchEvent) error {
+	if e.TeamID != match.HomeTeamID && e.TeamID != match.AwayTeamID {
+		return apperror.NewValidationError("tim tidak sesuai dengan tim yang bertanding")
+	}
+
+	if err := validateMinute(match, e.Minute); err != nil {
+		return err
+	}
+
+	lineup, _ := s.lineupRepo.GetByMatchTeam(e.MatchID, e.TeamID)
+
+	if err := s.ensureTeamPlayer(e.PlayerID, e.TeamID, lineup); err != nil {
+		return err
+	}
+	if err := s.ensureNotSuspended(e.PlayerID, e.TeamID, match); err != nil {
+		return err
+	}
+	if e.RelatedPlayerID != nil {
+		if err := s.ensureNotSuspended(*e.RelatedPlayerID, e.TeamID, match); err != nil {
+			return err
+		}
+	}
+
+	if e.RelatedPlayerID != nil && e.Type != EventGoal && e.Type != EventSubstitution {
+		return apperror.NewValidationError("related_player_id hanya untuk assist gol atau pemain keluar saat pergantian")
+	}
+
+	switch e.Type {
+	case EventGoal:
+		if e.RelatedPlayerID != nil {
+			if *e.RelatedPlayerID == e.PlayerID {
+				return apperror.NewValidationError("pemberi assist tidak boleh sama dengan pencetak gol")
+			}
+			if err := s.ensureTeamPlayer(*e.RelatedPlayerID, e.TeamID, lineup); err != nil {
+				return err
+			}
+		}
+	case EventSubstitution:
+		if e.RelatedPlayerID == nil {
+			return apperror.NewValidationError("pemain keluar (related_player_id) wajib diisi untuk pergantian")
+		}
+		if *e.RelatedPlayerID == e.PlayerID {
+			return apperror.NewValidationError("pemain masuk dan keluar tidak boleh sama")
+		}
+		if err := s.ensureTeamPlayer(*e.RelatedPlayerID, e.TeamID, lineup); err != nil {
+			return err
+		}
+	}
+
+	return checkEventState(e, newMatchPlayerState(prior), lineup)
+}
+
+func checkEventState(e *models.MatchEvent, state matchPlayerState, lineup *models.MatchLineup) error {
+	if err := state.available(e.PlayerID); err != nil {
+		return err
+	}
+
+	if lineup != nil {
+		if err := checkLineup(lineup, state, e); err != nil {
+			return err
+		}
+	}
+
+	switch e.Type {
+	case EventGoal:
+		if e.RelatedPlayerID != nil {
+			if err := state.available(*e.RelatedPlayerID); err != nil {
+				return err
+			}
+		}
+	case EventYellowCard:
+		if state.yellows[e.PlayerID] >= 1 {
+			return apperror.NewValidationError("pemain sudah mendapat kartu kuning sebelumnya")
+		}
+	case EventSecondYellow:
+		if state.yellows[e.PlayerID] != 1 {
+			return apperror.NewValidationError("kartu kuning kedua membutuhkan satu kartu kuning sebelumnya")
+		}
+	case EventSubstitution:
+		if e.RelatedPlayerID != nil {
+			if err := state.available(*e.RelatedPlayerID); err != nil {
+				return err
+			}
+		}
+		if state.subbedOn[e.PlayerID] {
+			return apperror.NewValidationError("pemain sudah masuk sebagai pengganti")
+		}
+		if state.subs[e.TeamID] >= maxSubstitutions {
+			return apperror.NewValidationError("jumlah pergantian pemain sudah mencapai batas")
+		}
+	}
+	return nil
+}
+
+func publishMatchEvent(
+	p live.Publisher,
+	matchRepo repository.MatchRepository,
+	eventRepo repository.MatchEventRepository,
+	e *models.MatchEvent,
+) {
+	match, err := matchRepo.GetByID(e.MatchID)
+	if err != nil {
+		return
+	}
+
+	events, err := eventRepo.GetByMatch(e.MatchID)
+	if err != nil {
+		return
+	}
+
+	for i := range events {
+		if events[i].ID == e.ID {
+			p.Publish(e.Type, e.MatchID, dto.ToLiveMatchDTO(match, &events[i]))
+			return
+		}
+	}
+}
+
+func (s *matchEventService) ensureTeamPlayer(playerID, teamID uint, lineup *models.MatchLineup) error {
+	player, err := s.playerRepo.GetByID(playerID)
+	if err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+
+	if lineup != nil {
+		for _, lp := range lineup.Players {
+			if lp.PlayerID == playerID {
+				return nil
+			}
+		}
+		return apperror.NewValidationError("pemain " + player.Name + " tidak terdaftar dalam susunan pemain tim")
+	}
+	if player.TeamID != teamID {
+		return apperror.NewValidationError("pemain " + player.Name + " bukan anggota tim yang dipilih")
+	}
+	return nil
+}
+
+func (s *matchEventService) ensureNotSuspended(playerID, teamID uint, match *models.Match) error {
+	player, err := s.playerRepo.GetByID(playerID)
+	if err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	return checkNotSuspended(s.suspensions, s.matchRepo, player, teamID, match)
+}
+
+func (s *matchEventService) Timeline(matchID uint) (*dto.MatchTimelineDTO, error) {
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	events, err := s.repo.GetByMatch(matchID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil event pertandingan")
+	}
+
+	linked := map[uint]bool{}
+	for _, e := range events {
+		if e.GoalID != nil {
+			linked[*e.GoalID] = true
+		}
+	}
+
+	for _, g := range match.Goals {
+		if linked[g.ID] {
+			continue
+		}
+		goalID := g.ID
+		eventType, ok := eventByGoalType[g.Type]
+		if !ok {
+			eventType = EventGoal
+		}
+		events = append(events, models.MatchEvent{
+			MatchID:         g.MatchID,
+			TeamID:          g.TeamID,
+			Team:            g.Team,
+			PlayerID:        g.ScorerPlayerID,
+			Player:          g.Scorer,
+			RelatedPlayerID: g.AssistPlayerID,
+			RelatedPlayer:   g.Assist,
+			GoalID:          &goalID,
+			Type:            eventType,
+			Minute:          g.Minute,
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if minuteBase(a.Minute) != minuteBase(b.Minute) {
+			return minuteBase(a.Minute) < minuteBase(b.Minute)
+		}
+		return minuteAdded(a.Minute) < minuteAdded(b.Minute)
+	})
+
+	timeline := dto.ToMatchTimelineDTO(match, events)
+	return &timeline, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+const (
+	startingPlayers = 11
+	maxBenchPlayers = 12
+)
+
+var formationPattern = regexp.MustCompile(`^[1-9](-[1-9]){1,4}$`)
+
+type MatchLineupService interface {
+	Submit(l *models.MatchLineup) error
+	GetByMatch(matchID uint) ([]models.MatchLineup, error)
+}
+
+type matchLineupService struct {
+	repo        repository.MatchLineupRepository
+	matchRepo   repository.MatchRepository
+	playerRepo  repository.PlayerRepository
+	suspensions repository.PlayerSuspensionRepository
+	uow         repository.UnitOfWork
+}
+
+func NewMatchLineupService(
+	r repository.MatchLineupRepository,
+	mr repository.MatchRepository,
+	pr repository.PlayerRepository,
+	sr repository.PlayerSuspensionRepository,
+	uow repository.UnitOfWork,
+) MatchLineupService {
+	return &matchLineupService{
+		repo:        r,
+		matchRepo:   mr,
+		playerRepo:  pr,
+		suspensions: sr,
+		uow:         uow,
+	}
+}
+
+func matchLineupServiceTx(r repository.Repositories) *matchLineupService {
+	return &matchLineupService{
+		repo:        r.MatchLineups,
+		matchRepo:   r.Matches,
+		playerRepo:  r.Players,
+		suspensions: r.Suspensions,
+	}
+}
+
+func validateFormation(formation string) error {
+	if !formationPattern.MatchString(formation) {
+		return apperror.NewValidationError("format formasi tidak valid (contoh: 4-3-3, 4-2-3-1)")
+	}
+
+	outfield := 0
+	for _, part := range strings.Split(formation, "-") {
+		n, _ := strconv.Atoi(part)
+		outfield += n
+	}
+	if outfield != startingPlayers-1 {
+		return apperror.NewValidationError("formasi harus berisi 10 pemain di luar penjaga gawang")
+	}
+	return nil
+}
+
+func (s *matchLineupService) Submit(l *models.MatchLineup) error {
+	return s.uow.Do(func(r repository.Repositories) error {
+		if err := matchLineupServiceTx(r).submit(l); err != nil {
+			return err
+		}
+
+		events, err := r.MatchEvents.GetByMatch(l.MatchID)
+		if err != nil {
+			return apperror.NewInternalError("gagal mengambil event pertandingan")
+		}
+		teamEvents := make([]models.MatchEvent, 0, len(events))
+		for _, e := range events {
+			if e.TeamID == l.TeamID {
+				teamEvents = append(teamEvents, e)
+			}
+		}
+		return matchEventServiceTx(r).checkSequence(l.MatchID, teamEvents)
+	})
+}
+
+func (s *matchLineupService) submit(l *models.MatchLineup) error {
+	if _, err := s.matchRepo.GetForUpdate(l.MatchID); err != nil {
+		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+	match, err := s.matchRepo.GetByID(l.MatchID)
+	if err != nil {
+		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	if l.TeamID != match.HomeTeamID && l.TeamID != match.AwayTeamID {
+		return apperror.NewValidationError("tim tidak sesuai dengan tim yang bertanding")
+	}
+
+	if isMatchClosed(match.Status) {
+		return apperror.NewValidationError("tidak dapat mengubah susunan pemain karena status pertandingan: " + match.Status)
+	}
+
+	if err := validateFormation(l.Formation); err != nil {
+		return err
+	}
+
+	starters := 0
+	bench := 0
+	keepers := 0
+	captainStarts := false
+	seen := map[uint]bool{}
+
+	for i := range l.Players {
+		lp := &l.Players[i]
+
+		if seen[lp.PlayerID] {
+			return apperror.NewValidationError("pemain tidak boleh tercantum lebih dari sekali")
+		}
+		seen[lp.PlayerID] = true
+
+		player, err := s.playerRepo.GetByID(lp.PlayerID)
+		if err != nil {
+			return apperror.NewNotFoundError("pemain " + strconv.Itoa(int(lp.PlayerID)) + " tidak ditemukan")
+		}
+		if player.TeamID != l.TeamID {
+			return apperror.NewValidationError("pemain " + player.Name + " bukan anggota tim")
+		}
+		if lp.JerseyNumber != player.JerseyNumber {
+			return apperror.NewValidationError("nomor punggung " + player.Name + " tidak sesuai (terdaftar " + strconv.Itoa(player.JerseyNumber) + ")")
+		}
+		if err := checkNotSuspended(s.suspensions, s.matchRepo, player, l.TeamID, match); err != nil {
+			return err
+		}
+
+		if lp.Starter {
+			starters++
+			if player.Position == "PENJAGA_GAWANG" {
+				keepers++
+			}
+			if lp.PlayerID == l.CaptainPlayerID {
+				captainStarts = true
+			}
+		} else {
+			bench++
+		}
+	}
+
+	if starters != startingPlayers {
+		return apperror.NewValidationError("susunan pemain inti harus tepat 11 pemain")
+	}
+	if keepers != 1 {
+		return apperror.NewValidationError("susunan pemain inti harus memiliki tepat satu PENJAGA_GAWANG")
+	}
+	if bench > maxBenchPlayers {
+		return apperror.NewValidationError("pemain cadangan maksimal " + strconv.Itoa(maxBenchPlayers))
+	}
+	if !captainStarts {
+		return apperror.NewValidationError("kapten harus termasuk pemain inti")
+	}
+
+	if err := s.repo.Save(l); err != nil {
+		return apperror.NewInternalError("gagal menyimpan susunan pemain")
+	}
+	return nil
+}
+
+func (s *matchLineupService) GetByMatch(matchID uint) ([]models.MatchLineup, error) {
+	if _, err := s.matchRepo.GetByID(matchID); err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	lineups, err := s.repo.GetByMatch(matchID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil susunan pemain")
+	}
+	return lineups, nil
+}
@@ -1,14 +1,19 @@
 package service
 
 import (
-	"sort"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"football-backend/internal/dto"
 	apperror "football-backend/internal/errors"
+	"football-backend/internal/live"
 	"football-backend/internal/models"
 	"football-backend/internal/repository"
 	"football-backend/internal/utils"
+
+	"gorm.io/gorm"
 )
 
 type MatchService interface {
@@ -17,18 +22,71 @@ type MatchService interface {
 	GetByID(id uint) (*models.Match, error)
 	GetList(q utils.QueryParams) (map[string]interface{}, error)
 	ProcessResult(matchID uint) error
+	SubmitResult(matchID uint, goals []models.Goal, extraTime bool) error
+	Transition(matchID uint, action string) (*models.Match, error)
+	Reschedule(matchID uint, at time.Time) (*models.Match, error)
+	LiveSnapshot(matchID uint) ([]dto.LiveMatchDTO, error)
 	Report(matchID uint) (map[string]interface{}, error)
-	LeagueStanding() ([]dto.StandingDTO, error)
+	LeagueStanding(seasonID uint, venue string) ([]dto.StandingDTO, error)
+	PositionHistory(seasonID uint) ([]dto.PositionHistoryDTO, error)
+	GenerateFixtures(opt FixtureOptions) ([]models.Match, error)
+	BackfillResults() (int, error)
 }
 
 type matchService struct {
-	repo     repository.MatchRepository
-	goalRepo repository.GoalRepository
-	teamRepo repository.TeamRepository
+	repo         repository.MatchRepository
+	goalRepo     repository.GoalRepository
+	teamRepo     repository.TeamRepository
+	seasonRepo   repository.SeasonRepository
+	ruleRepo     repository.StandingRuleRepository
+	sanctionRepo repository.TeamSanctionRepository
+	eventRepo    repository.MatchEventRepository
+	venueRepo    repository.VenueRepository
+	publisher    live.Publisher
+	uow          repository.UnitOfWork
+	venueWindow  time.Duration
+	officialGap  time.Duration
 }
 
-func NewMatchService(r repository.MatchRepository, g repository.GoalRepository, t repository.TeamRepository) MatchService {
-	return &matchService{repo: r, goalRepo: g, teamRepo: t}
+func NewMatchService(
+	r repository.MatchRepository,
+	g repository.GoalRepository,
+	t repository.TeamRepository,
+	sr repository.SeasonRepository,
+	rr repository.StandingRuleRepository,
+	tsr repository.TeamSanctionRepository,
+	er repository.MatchEventRepository,
+	vr repository.VenueRepository,
+	p live.Publisher,
+	uow repository.UnitOfWork,
+	venueWindow time.Duration,
+	officialGap time.Duration,
+) MatchService {
+	return &matchService{
+		repo:         r,
+		goalRepo:     g,
+		teamRepo:     t,
+		seasonRepo:   sr,
+		ruleRepo:     rr,
+		sanctionRepo: tsr,
+		eventRepo:    er,
+		venueRepo:    vr,
+		publisher:    p,
+		uow:          uow,
+		venueWindow:  venueWindow,
+		officialGap:  officialGap,
+	}
+}
+
+func matchServiceTx(r repository.Repositories, venueWindow time.Duration) *matchService {
+	return &matchService{
+		repo:        r.Matches,
+		goalRepo:    r.Goals,
+		teamRepo:    r.Teams,
+		seasonRepo:  r.Seasons,
+		eventRepo:   r.MatchEvents,
+		venueWindow: venueWindow,
+	}
 }
 
 func (s *matchService) Create(m *models.Match) error {
@@ -39,6 +97,12 @@ func (s *matchService) Create(m *models.Match) error {
 		return apperror.NewValidationError("home dan away team tidak boleh sama")
 	}
 
+	if m.SeasonID != nil {
+		if err := s.validateSeason(*m.SeasonID, m.MatchDateTime); err != nil {
+			return err
+		}
+	}
+
 	conflict, err := s.repo.CheckConflict(m.HomeTeamID, m.MatchDateTime)
 	if err != nil {
 		return apperror.NewInternalError("gagal memeriksa jadwal")
@@ -55,6 +119,19 @@ func (s *matchService) Create(m *models.Match) error {
 		return apperror.NewConflictError("jadwal bentrok dengan pertandingan lain (tim away)")
 	}
 
+	if m.VenueID == nil {
+		home, err := s.teamRepo.GetByID(m.HomeTeamID)
+		if err != nil {
+			return apperror.NewNotFoundError("team home tidak ditemukan")
+		}
+		m.VenueID = home.HomeVenueID
+	} else if _, err := s.venueRepo.GetByID(*m.VenueID); err != nil {
+		return apperror.NewNotFoundError("venue tidak ditemukan")
+	}
+	if err := s.checkVenueConflict(m.VenueID, 0, m.MatchDateTime); err != nil {
+		return err
+	}
+
 	if err := s.repo.Create(m); err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") {
 			return apperror.NewConflictError("jadwal pertandingan sudah ada")
@@ -64,7 +141,44 @@ func (s *matchService) Create(m *models.Match) error {
 	return nil
 }
 
+func (s *matchService) validateSeason(seasonID uint, matchDate time.Time) error {
+	season, err := s.seasonRepo.GetByID(seasonID)
+	if err != nil {
+		return apperror.NewNotFoundError("musim tidak ditemukan")
+	}
+
+	if !season.StartDate.IsZero() && !season.EndDate.IsZero() {
+		if matchDate.Before(season.StartDate) || matchDate.After(season.EndDate.AddDate(0, 0, 1)) {
+			return apperror.NewValidationError("tanggal pertandingan di luar periode musim " + season.Name)
+		}
+	}
+	return nil
+}
+
 func (s *matchService) Update(m *models.Match) error {
+	if m.SeasonID != nil {
+		if err := s.validateSeason(*m.SeasonID, m.MatchDateTime); err != nil {
+			return err
+		}
+	}
+
+	current, err := s.repo.GetByID(m.ID)
+	if err != nil {
+		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+	venueChanged := (current.VenueID == nil) != (m.VenueID == nil) ||
+		(m.VenueID != nil && *current.VenueID != *m.VenueID)
+	if venueChanged && m.VenueID != nil {
+		if _, err := s.venueRepo.GetByID(*m.VenueID); err != nil {
+			return apperror.NewNotFoundError("venue tidak ditemukan")
+		}
+	}
+	if venueChanged || !current.MatchDateTime.Equal(m.MatchDateTime) {
+		if err := s.checkVenueConflict(m.VenueID, m.ID, m.MatchDateTime); err != nil {
+			return err
+		}
+	}
+
 	if err := s.repo.Update(m); err != nil {
 		return apperror.NewInternalError("gagal memperbarui pertandingan")
 	}
@@ -100,11 +214,132 @@ func (s *matchService) GetList(q utils.QueryParams) (map[string]interface{}, err
 }
 
 func (s *matchService) ProcessResult(matchID uint) error {
-	match, err := s.repo.GetByID(matchID)
+	var match *models.Match
+	err := s.uow.Do(func(r repository.Repositories) error {
+		var err error
+		match, err = finishMatch(r, matchID, s.venueWindow)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishStatus(match)
+	return nil
+}
+
+func (s *matchService) SubmitResult(matchID uint, goals []models.Goal, extraTime bool) error {
+	var match *models.Match
+	events := make([]models.MatchEvent, 0, len(goals))
+
+	err := s.uow.Do(func(r repository.Repositories) error {
+		m, err := r.Matches.GetByID(matchID)
+		if err != nil {
+			return apperror.NewNotFoundError("pertandingan tidak ditemukan")
+		}
+
+		if extraTime {
+			if m.TieID == nil {
+				return apperror.NewValidationError("extra_time hanya untuk pertandingan knockout")
+			}
+			tie, err := r.Brackets.GetTieByID(*m.TieID)
+			if err != nil {
+				return apperror.NewNotFoundError("tie knockout tidak ditemukan")
+			}
+			if !tie.Bracket.ExtraTime {
+				return apperror.NewValidationError("bracket ini tidak memakai extra time")
+			}
+			m.ExtraTime = true
+			if err := r.Matches.Update(m); err != nil {
+				return apperror.NewInternalError("gagal memperbarui pertandingan")
+			}
+		}
+
+		eventSvc := matchEventServiceTx(r)
+		for i := range goals {
+			goals[i].MatchID = matchID
+			event, err := goalEvent(&goals[i])
+			if err != nil {
+				return err
+			}
+			if err := eventSvc.record(&event); err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+
+		match, err = finishMatch(r, matchID, s.venueWindow)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range events {
+		publishMatchEvent(s.publisher, s.repo, s.eventRepo, &events[i])
+	}
+	s.publishStatus(match)
+	return nil
+}
+
+func finishMatch(r repository.Repositories, matchID uint, venueWindow time.Duration) (*models.Match, error) {
+	match, err := r.Matches.GetByID(matchID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	status, err := checkTransition(ActionFinish, match.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := settleResult(r, match); err != nil {
+		return nil, err
+	}
+	match.Status = status
+
+	if err := r.Matches.Update(match); err != nil {
+		return nil, apperror.NewInternalError("gagal menyimpan hasil pertandingan")
+	}
+	if err := applyCardSuspensions(r, match, nil); err != nil {
+		return nil, err
+	}
+
+	if match.TieID != nil {
+		if err := bracketServiceTx(r, venueWindow).resolveMatch(match.ID); err != nil {
+			return nil, err
+		}
+	}
+	return match, nil
+}
+
+func reviseResult(r repository.Repositories, matchID uint, venueWindow time.Duration, recount []uint) error {
+	match, err := r.Matches.GetByID(matchID)
 	if err != nil {
 		return apperror.NewNotFoundError("pertandingan tidak ditemukan")
 	}
-	goals, err := s.goalRepo.GetGoals(matchID)
+	if match.Status != MatchFinished {
+		return nil
+	}
+
+	if err := settleResult(r, match); err != nil {
+		return err
+	}
+	if err := r.Matches.Update(match); err != nil {
+		return apperror.NewInternalError("gagal menyimpan hasil pertandingan")
+	}
+	if err := applyCardSuspensions(r, match, recount); err != nil {
+		return err
+	}
+
+	if match.TieID != nil {
+		return bracketServiceTx(r, venueWindow).reviseMatch(match.ID)
+	}
+	return nil
+}
+
+func settleResult(r repository.Repositories, match *models.Match) error {
+	goals, err := r.Goals.GetGoals(match.ID)
 	if err != nil {
 		return apperror.NewInternalError("gagal mengambil data gol")
 	}
@@ -113,25 +348,187 @@ func (s *matchService) ProcessResult(matchID uint) error {
 	awayScore := 0
 
 	for _, g := range goals {
-		if g.TeamID == match.HomeTeamID {
+		if dto.ScoringTeamID(&g, match) == match.HomeTeamID {
 			homeScore++
 		} else {
 			awayScore++
 		}
+		if match.TieID != nil && minuteBase(g.Minute) > 90 {
+			match.ExtraTime = true
+		}
 	}
 
+	result := ResultDraw
 	if homeScore > awayScore {
-		match.Status = "HOME_WIN"
+		result = ResultHomeWin
 	} else if awayScore > homeScore {
-		match.Status = "AWAY_WIN"
-	} else {
-		match.Status = "DRAW"
+		result = ResultAwayWin
+	}
+
+	match.Result = &result
+	return nil
+}
+
+func (s *matchService) BackfillResults() (int, error) {
+	ids, err := s.repo.GetFinishedWithoutResult()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range ids {
+		err := s.uow.Do(func(r repository.Repositories) error {
+			match, err := r.Matches.GetByID(id)
+			if err != nil {
+				return err
+			}
+			if err := settleResult(r, match); err != nil {
+				return err
+			}
+			return r.Matches.Update(match)
+		})
+		if err != nil {
+			return i, fmt.Errorf("match %d: %w", id, err)
+		}
 	}
+	return len(ids), nil
+}
 
+func (s *matchService) Transition(matchID uint, action string) (*models.Match, error) {
+	if action == ActionFinish {
+		if err := s.ProcessResult(matchID); err != nil {
+			return nil, err
+		}
+		return s.GetByID(matchID)
+	}
+	if action == ActionReschedule {
+		return nil, apperror.NewValidationError("gunakan jadwal ulang dengan tanggal baru")
+	}
+
+	match, err := s.repo.GetByID(matchID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	status, err := checkTransition(action, match.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	match.Status = status
 	if err := s.repo.Update(match); err != nil {
-		return apperror.NewInternalError("gagal menyimpan hasil pertandingan")
+		return nil, apperror.NewInternalError("gagal memperbarui status pertandingan")
 	}
-	return nil
+
+	s.publishStatus(match)
+	return match, nil
+}
+
+func (s *matchService) Reschedule(matchID uint, at time.Time) (*models.Match, error) {
+	match, err := s.repo.GetByID(matchID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	status, err := checkTransition(ActionReschedule, match.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if match.SeasonID != nil {
+		if err := s.validateSeason(*match.SeasonID, at); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, teamID := range []uint{match.HomeTeamID, match.AwayTeamID} {
+		conflict, err := s.repo.CheckConflict(teamID, at)
+		if err != nil {
+			return nil, apperror.NewInternalError("gagal memeriksa jadwal")
+		}
+		if conflict {
+			return nil, apperror.NewConflictError("jadwal baru bentrok dengan pertandingan lain")
+		}
+	}
+	if err := s.checkVenueConflict(match.VenueID, match.ID, at); err != nil {
+		return nil, err
+	}
+
+	err = s.uow.Do(func(r repository.Repositories) error {
+		assigned, err := r.MatchOfficials.GetByMatch(match.ID)
+		if err != nil {
+			return apperror.NewInternalError("gagal mengambil ofisial pertandingan")
+		}
+		officialIDs := make([]uint, 0, len(assigned))
+		for _, mo := range assigned {
+			officialIDs = append(officialIDs, mo.OfficialID)
+		}
+		if err := checkOfficialAvailability(r.MatchOfficials, s.officialGap, match.ID, officialIDs, at); err != nil {
+			return err
+		}
+
+		match.Status = status
+		match.MatchDateTime = at
+		if err := r.Matches.Update(match); err != nil {
+			return apperror.NewInternalError("gagal menjadwalkan ulang pertandingan")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishStatus(match)
+	return match, nil
+}
+
+func (s *matchService) checkVenueConflict(venueID *uint, matchID uint, at time.Time) error {
+	return checkVenueConflict(s.repo, s.venueWindow, venueID, matchID, at)
+}
+
+func checkVenueConflict(repo repository.MatchRepository, window time.Duration, venueID *uint, matchID uint, at time.Time) error {
+	if venueID == nil || window <= 0 {
+		return nil
+	}
+
+	other, err := repo.FindVenueConflict(*venueID, matchID, at.Add(-window), at.Add(window))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa jadwal venue")
+	}
+	return apperror.NewConflictError(fmt.Sprintf(
+		"venue sudah dipakai pertandingan %s vs %s pada %s",
+		other.HomeTeam.Name, other.AwayTeam.Name, other.MatchDateTime.Format(time.RFC3339),
+	))
+}
+
+func (s *matchService) venueOverlaps(a, b time.Time) bool {
+	diff := a.Sub(b)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff < s.venueWindow
+}
+
+func (s *matchService) publishStatus(match *models.Match) {
+	s.publisher.Publish("STATUS", match.ID, dto.ToLiveMatchDTO(match, nil))
+}
+
+func (s *matchService) LiveSnapshot(matchID uint) ([]dto.LiveMatchDTO, error) {
+	if matchID > 0 {
+		match, err := s.repo.GetByID(matchID)
+		if err != nil {
+			return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+		}
+		return []dto.LiveMatchDTO{dto.ToLiveMatchDTO(match, nil)}, nil
+	}
+
+	matches, err := s.repo.GetByStatuses([]string{MatchLive, MatchHalfTime})
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil pertandingan yang sedang berlangsung")
+	}
+	return dto.ToLiveMatchDTOList(matches), nil
 }
 
 func (s *matchService) Report(matchID uint) (map[string]interface{}, error) {
@@ -150,13 +547,15 @@ func (s *matchService) Report(matchID uint) (map[string]interface{}, error) {
 	goalList := []map[string]interface{}{}
 
 	for _, g := range goals {
-		if g.TeamID == match.HomeTeamID {
+		if dto.ScoringTeamID(&g, match) == match.HomeTeamID {
 			homeScore++
 		} else {
 			awayScore++
 		}
-		scorerCount[g.ScorerPlayerID]++
-		scorerDetail[g.ScorerPlayerID] = g.Scorer
+		if g.Type != "BUNUH_DIRI" {
+			scorerCount[g.ScorerPlayerID]++
+			scorerDetail[g.ScorerPlayerID] = g.Scorer
+		}
 
 		goalList = append(goalList, map[string]interface{}{
 			"player": map[string]interface{}{
@@ -166,6 +565,7 @@ func (s *matchService) Report(matchID uint) (map[string]interface{}, error) {
 				"jersey_number": g.Scorer.JerseyNumber,
 			},
 			"minute": g.Minute,
+			"type":   g.Type,
 		})
 	}
 
@@ -192,6 +592,14 @@ func (s *matchService) Report(matchID uint) (map[string]interface{}, error) {
 	homeWins, _ := s.repo.CountHomeWins(match.HomeTeamID)
 	awayWins, _ := s.repo.CountAwayWins(match.AwayTeamID)
 
+	meetings, _ := s.repo.GetHeadToHead(match.HomeTeamID, match.AwayTeamID)
+	previous := []models.Match{}
+	for _, m := range meetings {
+		if m.ID != match.ID && m.MatchDateTime.Before(match.MatchDateTime) {
+			previous = append(previous, m)
+		}
+	}
+
 	finalStatus := ""
 	if homeScore > awayScore {
 		finalStatus = "Tim Home Menang"
@@ -217,10 +625,35 @@ func (s *matchService) Report(matchID uint) (map[string]interface{}, error) {
 		"top_scorer": topScorer,
 		"home_wins":  homeWins,
 		"away_wins":  awayWins,
+
+		"head_to_head": buildHeadToHead(match.HomeTeamID, match.AwayTeamID, previous),
 	}, nil
 }
 
-func (s *matchService) LeagueStanding() ([]dto.StandingDTO, error) {
+func (s *matchService) LeagueStanding(seasonID uint, venue string) ([]dto.StandingDTO, error) {
+	if venue != "" && venue != VenueHome && venue != VenueAway {
+		return nil, apperror.NewValidationError("venue harus HOME atau AWAY")
+	}
+
+	teams, matches, cfg, err := s.standingSource(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.venue = venue
+	return buildStanding(teams, matches, cfg), nil
+}
+
+func (s *matchService) PositionHistory(seasonID uint) ([]dto.PositionHistoryDTO, error) {
+	teams, matches, cfg, err := s.standingSource(seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPositionHistory(teams, matches, cfg), nil
+}
+
+func (s *matchService) standingSource(seasonID uint) ([]models.Team, []models.Match, standingConfig, error) {
 	teams, _, err := s.teamRepo.GetAll(utils.QueryParams{
 		Page:    1,
 		Limit:   9999,
@@ -229,75 +662,37 @@ func (s *matchService) LeagueStanding() ([]dto.StandingDTO, error) {
 		Filters: map[string]map[utils.FilterOperator]string{},
 	})
 	if err != nil {
-		return nil, apperror.NewInternalError("gagal mengambil daftar tim")
+		return nil, nil, standingConfig{}, apperror.NewInternalError("gagal mengambil daftar tim")
 	}
 
-	matches, err := s.repo.GetFinishedMatches()
+	matches, err := s.repo.GetFinishedMatches(seasonID)
 	if err != nil {
-		return nil, apperror.NewInternalError("gagal mengambil pertandingan selesai")
+		return nil, nil, standingConfig{}, apperror.NewInternalError("gagal mengambil pertandingan selesai")
 	}
 
-	standing := map[uint]*dto.StandingDTO{}
-
-	for _, t := range teams {
-		standing[t.ID] = &dto.StandingDTO{
-			TeamID:   t.ID,
-			TeamName: t.Name,
+	participants := map[uint]bool{}
+	if seasonID > 0 {
+		ids, err := s.repo.GetTeamIDsBySeason(seasonID)
+		if err != nil {
+			return nil, nil, standingConfig{}, apperror.NewInternalError("gagal mengambil peserta musim")
 		}
-	}
-
-	for _, m := range matches {
-		home := standing[m.HomeTeamID]
-		away := standing[m.AwayTeamID]
-
-		homeGoals := 0
-		awayGoals := 0
-
-		for _, g := range m.Goals {
-			if g.TeamID == m.HomeTeamID {
-				homeGoals++
-			} else {
-				awayGoals++
-			}
+		for _, id := range ids {
+			participants[id] = true
 		}
+	}
 
-		home.Played++
-		away.Played++
-
-		home.GoalsFor += homeGoals
-		home.GoalsAgainst += awayGoals
-
-		away.GoalsFor += awayGoals
-		away.GoalsAgainst += homeGoals
-
-		if homeGoals > awayGoals {
-			home.Wins++
-			away.Losses++
-		} else if awayGoals > homeGoals {
-			away.Wins++
-			home.Losses++
-		} else {
-			home.Draws++
-			away.Draws++
+	selected := []models.Team{}
+	for _, t := range teams {
+		if seasonID > 0 && !participants[t.ID] {
+			continue
 		}
+		selected = append(selected, t)
 	}
 
-	list := []dto.StandingDTO{}
-	for _, s := range standing {
-		s.GoalDifference = s.GoalsFor - s.GoalsAgainst
-		s.Points = s.Wins*3 + s.Draws
-		list = append(list, *s)
+	cfg, err := loadStandingConfig(seasonID, s.seasonRepo, s.ruleRepo, s.sanctionRepo, s.eventRepo)
+	if err != nil {
+		return nil, nil, standingConfig{}, err
 	}
 
-	sort.Slice(list, func(i, j int) bool {
-		if list[i].Points != list[j].Points {
-			return list[i].Points > list[j].Points
-		}
-		if list[i].GoalDifference != list[j].GoalDifference {
-			return list[i].GoalDifference > list[j].GoalDifference
-		}
-		return list[i].GoalsFor > list[j].GoalsFor
-	})
-
-	return list, nil
+	return selected, matches, cfg, nil
 }
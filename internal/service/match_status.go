@@ -0,0 +1,75 @@
+package service
+
+import (
+	"strings"
+
+	apperror "football-backend/internal/errors"
+)
+
+const (
+	MatchScheduled = "DIJADWALKAN"
+	MatchLive      = "SEDANG BERLANGSUNG"
+	MatchHalfTime  = "ISTIRAHAT"
+	MatchFinished  = "SELESAI"
+	MatchPostponed = "DITUNDA"
+	MatchAbandoned = "DIHENTIKAN"
+	MatchCancelled = "DIBATALKAN"
+)
+
+const (
+	ResultHomeWin = "HOME_WIN"
+	ResultAwayWin = "AWAY_WIN"
+	ResultDraw    = "DRAW"
+)
+
+const (
+	ActionStart      = "start"
+	ActionHalfTime   = "half-time"
+	ActionResume     = "resume"
+	ActionFinish     = "finish"
+	ActionPostpone   = "postpone"
+	ActionAbandon    = "abandon"
+	ActionCancel     = "cancel"
+	ActionReschedule = "reschedule"
+)
+
+type matchTransition struct {
+	from []string
+	to   string
+}
+
+var matchTransitions = map[string]matchTransition{
+	ActionStart:      {from: []string{MatchScheduled}, to: MatchLive},
+	ActionHalfTime:   {from: []string{MatchLive}, to: MatchHalfTime},
+	ActionResume:     {from: []string{MatchHalfTime}, to: MatchLive},
+	ActionFinish:     {from: []string{MatchLive}, to: MatchFinished},
+	ActionPostpone:   {from: []string{MatchScheduled}, to: MatchPostponed},
+	ActionAbandon:    {from: []string{MatchLive, MatchHalfTime}, to: MatchAbandoned},
+	ActionCancel:     {from: []string{MatchScheduled, MatchPostponed, MatchAbandoned}, to: MatchCancelled},
+	ActionReschedule: {from: []string{MatchPostponed}, to: MatchScheduled},
+}
+
+func checkTransition(action, current string) (string, error) {
+	t, ok := matchTransitions[action]
+	if !ok {
+		return "", apperror.NewValidationError("aksi status tidak dikenal: " + action)
+	}
+
+	for _, from := range t.from {
+		if from == current {
+			return t.to, nil
+		}
+	}
+
+	return "", apperror.NewValidationError(
+		"tidak dapat " + action + " pertandingan berstatus " + current + " (hanya dari " + strings.Join(t.from, ", ") + ")",
+	)
+}
+
+func isMatchInPlay(status string) bool {
+	return status == MatchLive || status == MatchHalfTime
+}
+
+func isMatchClosed(status string) bool {
+	return status == MatchFinished || status == MatchAbandoned || status == MatchCancelled
+}
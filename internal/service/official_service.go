@@ -0,0 +1,211 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"football-backend/internal/dto"
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+	"football-backend/internal/utils"
+)
+
+const (
+	OfficialReferee    = "WASIT"
+	OfficialAssistant1 = "ASISTEN_WASIT_1"
+	OfficialAssistant2 = "ASISTEN_WASIT_2"
+	OfficialFourth     = "OFISIAL_KEEMPAT"
+)
+
+var officialRoles = []string{OfficialReferee, OfficialAssistant1, OfficialAssistant2, OfficialFourth}
+
+type OfficialService interface {
+	Create(o *models.Official) error
+	GetList(q utils.QueryParams) (map[string]interface{}, error)
+	GetByID(id uint) (*models.Official, error)
+	Update(o *models.Official) error
+	Delete(id uint) error
+	Stats(officialID uint, f repository.OfficialStatFilter) (*dto.OfficialStatDTO, error)
+	MatchOfficials(matchID uint) ([]models.MatchOfficial, error)
+	AssignMatch(matchID uint, assignments map[string]uint) ([]models.MatchOfficial, error)
+}
+
+type officialService struct {
+	repo       repository.OfficialRepository
+	assignRepo repository.MatchOfficialRepository
+	matchRepo  repository.MatchRepository
+	uow        repository.UnitOfWork
+	gap        time.Duration
+}
+
+func NewOfficialService(r repository.OfficialRepository, ar repository.MatchOfficialRepository, mr repository.MatchRepository, uow repository.UnitOfWork, gap time.Duration) OfficialService {
+	return &officialService{repo: r, assignRepo: ar, matchRepo: mr, uow: uow, gap: gap}
+}
+
+func (s *officialService) Create(o *models.Official) error {
+	if o.Name == "" {
+		return apperror.NewValidationError("nama ofisial wajib diisi")
+	}
+	if err := s.repo.Create(o); err != nil {
+		return apperror.NewInternalError("gagal membuat ofisial")
+	}
+	return nil
+}
+
+func (s *officialService) GetList(q utils.QueryParams) (map[string]interface{}, error) {
+	items, total, err := s.repo.GetAll(q)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data ofisial")
+	}
+
+	totalPages := 0
+	if q.Limit > 0 {
+		totalPages = int((total + int64(q.Limit) - 1) / int64(q.Limit))
+	}
+
+	return map[string]interface{}{
+		"items": items,
+		"pagination": map[string]interface{}{
+			"page":        q.Page,
+			"limit":       q.Limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	}, nil
+}
+
+func (s *officialService) GetByID(id uint) (*models.Official, error) {
+	o, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("ofisial tidak ditemukan")
+	}
+	return o, nil
+}
+
+func (s *officialService) Update(o *models.Official) error {
+	if o.Name == "" {
+		return apperror.NewValidationError("nama ofisial wajib diisi")
+	}
+	if err := s.repo.Update(o); err != nil {
+		return apperror.NewInternalError("gagal memperbarui ofisial")
+	}
+	return nil
+}
+
+func (s *officialService) Delete(id uint) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return apperror.NewNotFoundError("ofisial tidak ditemukan")
+	}
+
+	upcoming, err := s.assignRepo.CountUpcomingByOfficial(id)
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa penugasan ofisial")
+	}
+	if upcoming > 0 {
+		return apperror.NewConflictError("ofisial masih ditugaskan pada pertandingan yang belum selesai")
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return apperror.NewInternalError("gagal menghapus ofisial")
+	}
+	return nil
+}
+
+func (s *officialService) Stats(officialID uint, f repository.OfficialStatFilter) (*dto.OfficialStatDTO, error) {
+	o, err := s.repo.GetByID(officialID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("ofisial tidak ditemukan")
+	}
+
+	stats, err := s.repo.Stats(officialID, f)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil statistik ofisial")
+	}
+
+	stats.Official = dto.ToOfficialDTO(o)
+	if stats.MatchesAsReferee > 0 {
+		cards := stats.YellowCards + stats.SecondYellowCards + stats.RedCards
+		stats.CardsPerMatch = math.Round(float64(cards)/float64(stats.MatchesAsReferee)*100) / 100
+	}
+	return stats, nil
+}
+
+func (s *officialService) MatchOfficials(matchID uint) ([]models.MatchOfficial, error) {
+	if _, err := s.matchRepo.GetByID(matchID); err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+
+	list, err := s.assignRepo.GetByMatch(matchID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil ofisial pertandingan")
+	}
+	return list, nil
+}
+
+func (s *officialService) AssignMatch(matchID uint, assignments map[string]uint) ([]models.MatchOfficial, error) {
+	match, err := s.matchRepo.GetByID(matchID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+	}
+	if match.Status == MatchCancelled {
+		return nil, apperror.NewValidationError("tidak dapat menugaskan ofisial pada pertandingan yang dibatalkan")
+	}
+
+	list := make([]models.MatchOfficial, 0, len(officialRoles))
+	ids := make([]uint, 0, len(officialRoles))
+	roleOf := map[uint]string{}
+	for _, role := range officialRoles {
+		officialID, ok := assignments[role]
+		if !ok || officialID == 0 {
+			continue
+		}
+		if other, taken := roleOf[officialID]; taken {
+			return nil, apperror.NewValidationError(fmt.Sprintf(
+				"ofisial %d tidak boleh bertugas sebagai %s dan %s dalam satu pertandingan", officialID, other, role,
+			))
+		}
+		if _, err := s.repo.GetByID(officialID); err != nil {
+			return nil, apperror.NewNotFoundError(fmt.Sprintf("ofisial %d tidak ditemukan", officialID))
+		}
+
+		roleOf[officialID] = role
+		ids = append(ids, officialID)
+		list = append(list, models.MatchOfficial{OfficialID: officialID, Role: role})
+	}
+
+	err = s.uow.Do(func(r repository.Repositories) error {
+		if err := checkOfficialAvailability(r.MatchOfficials, s.gap, match.ID, ids, match.MatchDateTime); err != nil {
+			return err
+		}
+		if err := r.MatchOfficials.Replace(match.ID, list); err != nil {
+			return apperror.NewInternalError("gagal menyimpan ofisial pertandingan")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.MatchOfficials(match.ID)
+}
+
+func checkOfficialAvailability(repo repository.MatchOfficialRepository, gap time.Duration, matchID uint, officialIDs []uint, at time.Time) error {
+	if gap <= 0 {
+		return nil
+	}
+	overlapping, err := repo.FindOverlapping(officialIDs, matchID, at.Add(-gap), at.Add(gap))
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa jadwal ofisial")
+	}
+	if len(overlapping) == 0 {
+		return nil
+	}
+
+	o := overlapping[0]
+	return apperror.NewConflictError(fmt.Sprintf(
+		"jadwal bentrok: ofisial %s sudah bertugas pada pertandingan %d (%s)",
+		o.Official.Name, o.MatchID, o.Match.MatchDateTime.Format(time.RFC3339),
+	))
+}
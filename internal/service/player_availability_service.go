@@ -0,0 +1,441 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"football-backend/internal/dto"
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	SuspensionYellowAccumulation = "AKUMULASI_KARTU_KUNING"
+	SuspensionSecondYellow       = "KARTU_KUNING_KEDUA"
+	SuspensionRedCard            = "KARTU_MERAH"
+	SuspensionManual             = "MANUAL"
+
+	AvailabilityInjured   = "CEDERA"
+	AvailabilitySuspended = "SKORSING"
+)
+
+type PlayerAvailabilityService interface {
+	AddInjury(i *models.PlayerInjury) error
+	UpdateInjury(playerID, injuryID uint, expectedReturn, returnedAt *time.Time) (*models.PlayerInjury, error)
+	Injuries(playerID uint) ([]models.PlayerInjury, error)
+	AddSuspension(s *models.PlayerSuspension) error
+	Suspensions(playerID uint) ([]dto.PlayerSuspensionDTO, error)
+	DeleteSuspension(playerID, suspensionID uint) error
+	Unavailable(teamID, matchID uint) (*dto.TeamUnavailableDTO, error)
+}
+
+type playerAvailabilityService struct {
+	injuryRepo      repository.PlayerInjuryRepository
+	suspensionRepo  repository.PlayerSuspensionRepository
+	playerRepo      repository.PlayerRepository
+	teamRepo        repository.TeamRepository
+	matchRepo       repository.MatchRepository
+	competitionRepo repository.CompetitionRepository
+}
+
+func NewPlayerAvailabilityService(
+	ir repository.PlayerInjuryRepository,
+	sr repository.PlayerSuspensionRepository,
+	pr repository.PlayerRepository,
+	tr repository.TeamRepository,
+	mr repository.MatchRepository,
+	cr repository.CompetitionRepository,
+) PlayerAvailabilityService {
+	return &playerAvailabilityService{
+		injuryRepo:      ir,
+		suspensionRepo:  sr,
+		playerRepo:      pr,
+		teamRepo:        tr,
+		matchRepo:       mr,
+		competitionRepo: cr,
+	}
+}
+
+func defaultDisciplineRule() models.DisciplineRule {
+	return models.DisciplineRule{
+		YellowCardThreshold: 5,
+		YellowCardBan:       1,
+		SecondYellowBan:     1,
+		RedCardBan:          1,
+	}
+}
+
+func suspensionCoverage(matchRepo repository.MatchRepository, s *models.PlayerSuspension, teamID uint) ([]models.Match, error) {
+	if s.Matches <= 0 {
+		return nil, nil
+	}
+	return matchRepo.GetTeamMatchesAfter(teamID, s.CompetitionID, s.StartsAt, s.Matches)
+}
+
+func coversMatch(matches []models.Match, matchID uint) bool {
+	for _, m := range matches {
+		if m.ID == matchID {
+			return true
+		}
+	}
+	return false
+}
+
+func servedMatches(matches []models.Match) int {
+	served := 0
+	for _, m := range matches {
+		if m.Status == MatchFinished {
+			served++
+		}
+	}
+	return served
+}
+
+func checkNotSuspended(
+	suspensionRepo repository.PlayerSuspensionRepository,
+	matchRepo repository.MatchRepository,
+	player *models.Player,
+	teamID uint,
+	match *models.Match,
+) error {
+	if match.Season == nil {
+		return nil
+	}
+
+	list, err := suspensionRepo.GetByPlayerCompetition(player.ID, match.Season.CompetitionID, match.MatchDateTime)
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa skorsing pemain")
+	}
+
+	for i := range list {
+		covered, err := suspensionCoverage(matchRepo, &list[i], teamID)
+		if err != nil {
+			return apperror.NewInternalError("gagal memeriksa skorsing pemain")
+		}
+		if coversMatch(covered, match.ID) {
+			return apperror.NewValidationError("pemain " + player.Name + " sedang menjalani skorsing (" + list[i].Reason + ")")
+		}
+	}
+	return nil
+}
+
+func applyCardSuspensions(r repository.Repositories, match *models.Match, recount []uint) error {
+	if match.Season == nil {
+		return nil
+	}
+	competitionID := match.Season.CompetitionID
+
+	accumulated, err := r.Suspensions.GetAutoByMatch(match.ID, SuspensionYellowAccumulation)
+	if err != nil {
+		return apperror.NewInternalError("gagal memperbarui skorsing pemain")
+	}
+	if err := r.Suspensions.DeleteAutoByMatch(match.ID); err != nil {
+		return apperror.NewInternalError("gagal memperbarui skorsing pemain")
+	}
+
+	rule := defaultDisciplineRule()
+	saved, err := r.DisciplineRules.GetByCompetition(competitionID)
+	if err == nil {
+		rule = *saved
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.NewInternalError("gagal mengambil aturan disiplin")
+	}
+
+	events, err := r.MatchEvents.GetByMatch(match.ID)
+	if err != nil {
+		return apperror.NewInternalError("gagal mengambil event pertandingan")
+	}
+
+	yellows := map[uint]bool{}
+	for _, id := range recount {
+		yellows[id] = true
+	}
+	for _, sp := range accumulated {
+		yellows[sp.PlayerID] = true
+	}
+
+	secondYellow := map[uint]bool{}
+	red := map[uint]bool{}
+	seen := map[uint]bool{}
+	playerIDs := []uint{}
+	for _, e := range events {
+		switch e.Type {
+		case EventYellowCard:
+			yellows[e.PlayerID] = true
+			continue
+		case EventSecondYellow:
+			secondYellow[e.PlayerID] = true
+		case EventRedCard:
+			red[e.PlayerID] = true
+		default:
+			continue
+		}
+		if !seen[e.PlayerID] {
+			seen[e.PlayerID] = true
+			playerIDs = append(playerIDs, e.PlayerID)
+		}
+	}
+	sort.Slice(playerIDs, func(i, j int) bool { return playerIDs[i] < playerIDs[j] })
+
+	for _, playerID := range playerIDs {
+		if red[playerID] {
+			if err := createAutoSuspension(r, playerID, competitionID, match, SuspensionRedCard, rule.RedCardBan); err != nil {
+				return err
+			}
+		} else if secondYellow[playerID] {
+			if err := createAutoSuspension(r, playerID, competitionID, match, SuspensionSecondYellow, rule.SecondYellowBan); err != nil {
+				return err
+			}
+		}
+	}
+
+	if rule.YellowCardThreshold <= 0 {
+		return nil
+	}
+	yellowIDs := make([]uint, 0, len(yellows))
+	for id := range yellows {
+		yellowIDs = append(yellowIDs, id)
+	}
+	sort.Slice(yellowIDs, func(i, j int) bool { return yellowIDs[i] < yellowIDs[j] })
+
+	for _, playerID := range yellowIDs {
+		if err := reconcileYellowAccumulation(r, playerID, *match.SeasonID, competitionID, rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reconcileYellowAccumulation(r repository.Repositories, playerID, seasonID, competitionID uint, rule models.DisciplineRule) error {
+	if err := r.Suspensions.DeleteAutoBySeason(playerID, seasonID, SuspensionYellowAccumulation); err != nil {
+		return apperror.NewInternalError("gagal memperbarui skorsing pemain")
+	}
+
+	cards, err := r.MatchEvents.GetPlayerSeasonCards(playerID, seasonID, []string{EventYellowCard})
+	if err != nil {
+		return apperror.NewInternalError("gagal menghitung akumulasi kartu")
+	}
+
+	threshold := int64(rule.YellowCardThreshold)
+	total := int64(0)
+	for i := 0; i < len(cards); {
+		source := cards[i].Match
+		before := total
+		for ; i < len(cards) && cards[i].MatchID == source.ID; i++ {
+			total++
+		}
+		if total/threshold > before/threshold {
+			if err := createAutoSuspension(r, playerID, competitionID, &source, SuspensionYellowAccumulation, rule.YellowCardBan); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func createAutoSuspension(r repository.Repositories, playerID, competitionID uint, source *models.Match, reason string, matches int) error {
+	if matches <= 0 {
+		return nil
+	}
+	sp := models.PlayerSuspension{
+		PlayerID:      playerID,
+		CompetitionID: competitionID,
+		SourceMatchID: &source.ID,
+		Reason:        reason,
+		Matches:       matches,
+		StartsAt:      source.MatchDateTime,
+	}
+	if err := r.Suspensions.Create(&sp); err != nil {
+		return apperror.NewInternalError("gagal menyimpan skorsing pemain")
+	}
+	return nil
+}
+
+func (s *playerAvailabilityService) AddInjury(i *models.PlayerInjury) error {
+	if _, err := s.playerRepo.GetByID(i.PlayerID); err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	if strings.TrimSpace(i.Type) == "" {
+		return apperror.NewValidationError("jenis cedera wajib diisi")
+	}
+	if i.StartDate.IsZero() {
+		i.StartDate = time.Now()
+	}
+	if !i.ExpectedReturn.After(i.StartDate) {
+		return apperror.NewValidationError("perkiraan kembali harus setelah tanggal cedera")
+	}
+
+	if err := s.injuryRepo.Create(i); err != nil {
+		return apperror.NewInternalError("gagal menyimpan data cedera")
+	}
+	return nil
+}
+
+func (s *playerAvailabilityService) UpdateInjury(playerID, injuryID uint, expectedReturn, returnedAt *time.Time) (*models.PlayerInjury, error) {
+	injury, err := s.injuryRepo.GetByID(injuryID)
+	if err != nil || injury.PlayerID != playerID {
+		return nil, apperror.NewNotFoundError("data cedera tidak ditemukan")
+	}
+
+	if expectedReturn != nil {
+		if !expectedReturn.After(injury.StartDate) {
+			return nil, apperror.NewValidationError("perkiraan kembali harus setelah tanggal cedera")
+		}
+		injury.ExpectedReturn = *expectedReturn
+	}
+	if returnedAt != nil {
+		if returnedAt.Before(injury.StartDate) {
+			return nil, apperror.NewValidationError("tanggal kembali tidak boleh sebelum tanggal cedera")
+		}
+		injury.ReturnedAt = returnedAt
+	}
+
+	if err := s.injuryRepo.Update(injury); err != nil {
+		return nil, apperror.NewInternalError("gagal memperbarui data cedera")
+	}
+	return injury, nil
+}
+
+func (s *playerAvailabilityService) Injuries(playerID uint) ([]models.PlayerInjury, error) {
+	if _, err := s.playerRepo.GetByID(playerID); err != nil {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+
+	list, err := s.injuryRepo.GetByPlayer(playerID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data cedera")
+	}
+	return list, nil
+}
+
+func (s *playerAvailabilityService) AddSuspension(sp *models.PlayerSuspension) error {
+	if _, err := s.playerRepo.GetByID(sp.PlayerID); err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	if _, err := s.competitionRepo.GetByID(sp.CompetitionID); err != nil {
+		return apperror.NewNotFoundError("kompetisi tidak ditemukan")
+	}
+	if sp.Matches <= 0 {
+		return apperror.NewValidationError("jumlah pertandingan skorsing harus lebih dari 0")
+	}
+	if strings.TrimSpace(sp.Note) == "" {
+		return apperror.NewValidationError("alasan skorsing wajib diisi")
+	}
+	if sp.StartsAt.IsZero() {
+		sp.StartsAt = time.Now()
+	}
+	sp.Reason = SuspensionManual
+	sp.SourceMatchID = nil
+
+	if err := s.suspensionRepo.Create(sp); err != nil {
+		return apperror.NewInternalError("gagal menyimpan skorsing pemain")
+	}
+	return nil
+}
+
+func (s *playerAvailabilityService) Suspensions(playerID uint) ([]dto.PlayerSuspensionDTO, error) {
+	player, err := s.playerRepo.GetByID(playerID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+
+	list, err := s.suspensionRepo.GetByPlayer(playerID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data skorsing")
+	}
+
+	result := make([]dto.PlayerSuspensionDTO, 0, len(list))
+	for i := range list {
+		covered, err := suspensionCoverage(s.matchRepo, &list[i], player.TeamID)
+		if err != nil {
+			return nil, apperror.NewInternalError("gagal mengambil data skorsing")
+		}
+		result = append(result, dto.ToPlayerSuspensionDTO(&list[i], servedMatches(covered)))
+	}
+	return result, nil
+}
+
+func (s *playerAvailabilityService) DeleteSuspension(playerID, suspensionID uint) error {
+	sp, err := s.suspensionRepo.GetByID(suspensionID)
+	if err != nil || sp.PlayerID != playerID {
+		return apperror.NewNotFoundError("skorsing tidak ditemukan")
+	}
+
+	if err := s.suspensionRepo.Delete(suspensionID); err != nil {
+		return apperror.NewInternalError("gagal menghapus skorsing")
+	}
+	return nil
+}
+
+func (s *playerAvailabilityService) Unavailable(teamID, matchID uint) (*dto.TeamUnavailableDTO, error) {
+	if _, err := s.teamRepo.GetByID(teamID); err != nil {
+		return nil, apperror.NewNotFoundError("team tidak ditemukan")
+	}
+
+	var match *models.Match
+	if matchID > 0 {
+		m, err := s.matchRepo.GetByID(matchID)
+		if err != nil {
+			return nil, apperror.NewNotFoundError("pertandingan tidak ditemukan")
+		}
+		if m.HomeTeamID != teamID && m.AwayTeamID != teamID {
+			return nil, apperror.NewValidationError("team tidak bermain di pertandingan ini")
+		}
+		match = m
+	} else {
+		m, err := s.matchRepo.GetNextByTeam(teamID, time.Now())
+		if err != nil {
+			return nil, apperror.NewNotFoundError("tidak ada pertandingan mendatang untuk team ini")
+		}
+		match = m
+	}
+
+	players := []dto.UnavailablePlayerDTO{}
+
+	injuries, err := s.injuryRepo.GetActiveByTeam(teamID, match.MatchDateTime)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data cedera")
+	}
+	for i := range injuries {
+		injury := dto.ToPlayerInjuryDTO(&injuries[i])
+		players = append(players, dto.UnavailablePlayerDTO{
+			Player: injury.Player,
+			Status: AvailabilityInjured,
+			Injury: &injury,
+		})
+	}
+
+	if match.Season != nil {
+		suspensions, err := s.suspensionRepo.GetByTeamCompetition(teamID, match.Season.CompetitionID, match.MatchDateTime)
+		if err != nil {
+			return nil, apperror.NewInternalError("gagal mengambil data skorsing")
+		}
+		for i := range suspensions {
+			covered, err := suspensionCoverage(s.matchRepo, &suspensions[i], teamID)
+			if err != nil {
+				return nil, apperror.NewInternalError("gagal mengambil data skorsing")
+			}
+			if !coversMatch(covered, match.ID) {
+				continue
+			}
+
+			suspension := dto.ToPlayerSuspensionDTO(&suspensions[i], servedMatches(covered))
+			players = append(players, dto.UnavailablePlayerDTO{
+				Player:     suspension.Player,
+				Status:     AvailabilitySuspended,
+				Suspension: &suspension,
+			})
+		}
+	}
+
+	return &dto.TeamUnavailableDTO{
+		TeamID:  teamID,
+		Match:   dto.ToUpcomingMatchDTO(match, teamID),
+		Players: players,
+	}, nil
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+const (
+	SquadRoleStarter  = "INTI"
+	SquadRoleRotation = "ROTASI"
+	SquadRoleYouth    = "PEMAIN_MUDA"
+	SquadRoleBackup   = "CADANGAN"
+
+	maxContractYears    = 5
+	defaultExpiringDays = 90
+	maxExpiringDays     = 365
+)
+
+func validSquadRole(role string) bool {
+	valid := map[string]bool{
+		SquadRoleStarter:  true,
+		SquadRoleRotation: true,
+		SquadRoleYouth:    true,
+		SquadRoleBackup:   true,
+	}
+	return valid[role]
+}
+
+func validateContract(c *models.PlayerContract, now time.Time) error {
+	if c.SquadRole == "" {
+		c.SquadRole = SquadRoleRotation
+	}
+	if !validSquadRole(c.SquadRole) {
+		return apperror.NewValidationError("squad_role harus salah satu dari INTI, ROTASI, PEMAIN_MUDA, CADANGAN")
+	}
+	if c.StartDate.IsZero() {
+		c.StartDate = now
+	}
+	if !c.EndDate.After(c.StartDate) {
+		return apperror.NewValidationError("akhir kontrak harus setelah awal kontrak")
+	}
+	if !c.EndDate.After(now) {
+		return apperror.NewValidationError("akhir kontrak harus di masa depan")
+	}
+	if c.EndDate.After(now.AddDate(maxContractYears, 0, 0)) {
+		return apperror.NewValidationError(fmt.Sprintf("durasi kontrak maksimal %d tahun", maxContractYears))
+	}
+	return nil
+}
+
+func isForeignPlayer(nationality, country string) bool {
+	return nationality != "" && country != "" && !strings.EqualFold(nationality, country)
+}
+
+func checkSquadLimits(r repository.Repositories, teamID, playerID uint, nationality string) error {
+	rules, err := r.SquadRules.GetForTeam(teamID)
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa batas skuad")
+	}
+
+	for _, rule := range rules {
+		if rule.MaxSquadSize > 0 {
+			size, err := r.Players.CountByTeam(teamID, playerID)
+			if err != nil {
+				return apperror.NewInternalError("gagal memeriksa batas skuad")
+			}
+			if size >= int64(rule.MaxSquadSize) {
+				return apperror.NewValidationError(fmt.Sprintf(
+					"skuad tim sudah mencapai batas %d pemain untuk kompetisi %s", rule.MaxSquadSize, rule.Competition.Name,
+				))
+			}
+		}
+
+		if rule.MaxForeignPlayers > 0 && isForeignPlayer(nationality, rule.Competition.Country) {
+			foreign, err := r.Players.CountForeignByTeam(teamID, playerID, rule.Competition.Country)
+			if err != nil {
+				return apperror.NewInternalError("gagal memeriksa batas skuad")
+			}
+			if foreign >= int64(rule.MaxForeignPlayers) {
+				return apperror.NewValidationError(fmt.Sprintf(
+					"kuota pemain asing tim sudah mencapai batas %d untuk kompetisi %s", rule.MaxForeignPlayers, rule.Competition.Name,
+				))
+			}
+		}
+	}
+	return nil
+}
+
+func signContract(r repository.Repositories, player *models.Player, c *models.PlayerContract, now time.Time) error {
+	if err := r.PlayerContracts.TerminateOpen(player.ID, now); err != nil {
+		return apperror.NewInternalError("gagal mengakhiri kontrak lama")
+	}
+
+	c.PlayerID = player.ID
+	c.TeamID = player.TeamID
+	c.JerseyNumber = player.JerseyNumber
+	if err := r.PlayerContracts.Create(c); err != nil {
+		return apperror.NewInternalError("gagal menyimpan kontrak")
+	}
+	return nil
+}
+
+func syncContractJersey(r repository.Repositories, player *models.Player) error {
+	contract, err := r.PlayerContracts.FindCurrent(player.ID)
+	if err != nil || contract.TeamID != player.TeamID || contract.JerseyNumber == player.JerseyNumber {
+		return nil
+	}
+
+	contract.JerseyNumber = player.JerseyNumber
+	if err := r.PlayerContracts.Update(contract); err != nil {
+		return apperror.NewInternalError("gagal memperbarui kontrak")
+	}
+	return nil
+}
+
+func (s *playerService) Contracts(playerID uint) ([]models.PlayerContract, error) {
+	if _, err := s.repo.GetByID(playerID); err != nil {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+
+	list, err := s.contractRepo.GetByPlayer(playerID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data kontrak")
+	}
+	return list, nil
+}
+
+func (s *playerService) UpdateContract(playerID uint, changes *models.PlayerContract) (*models.PlayerContract, error) {
+	player, err := s.repo.GetByID(playerID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+
+	now := time.Now()
+	err = s.uow.Do(func(r repository.Repositories) error {
+		contract, err := r.PlayerContracts.FindCurrent(playerID)
+		if err != nil {
+			if _, err := r.PlayerLoans.FindOpenByPlayer(playerID); err == nil {
+				return apperror.NewValidationError("pemain sedang atau akan dipinjamkan tanpa kontrak di klub induk")
+			}
+
+			if err := validateContract(changes, now); err != nil {
+				return err
+			}
+			return signContract(r, player, changes, now)
+		}
+
+		if changes.SquadRole != "" {
+			contract.SquadRole = changes.SquadRole
+		}
+		contract.EndDate = changes.EndDate
+		if err := validateContract(contract, now); err != nil {
+			return err
+		}
+
+		if err := r.PlayerContracts.Update(contract); err != nil {
+			return apperror.NewInternalError("gagal memperbarui kontrak")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := s.contractRepo.FindCurrent(playerID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data kontrak")
+	}
+	return contract, nil
+}
+
+func (s *playerService) ExpiringContracts(days int, teamID uint) ([]models.PlayerContract, error) {
+	if days < 0 {
+		return nil, apperror.NewValidationError("days tidak boleh negatif")
+	}
+	if days == 0 {
+		days = defaultExpiringDays
+	}
+	if days > maxExpiringDays {
+		days = maxExpiringDays
+	}
+
+	now := time.Now()
+	list, err := s.contractRepo.GetExpiring(now, now.AddDate(0, 0, days), teamID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data kontrak")
+	}
+	return list, nil
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+)
+
+const (
+	LoanScheduled = "DIJADWALKAN"
+	LoanActive    = "AKTIF"
+	LoanEnded     = "SELESAI"
+	LoanPermanent = "DIPERMANENKAN"
+	LoanCancelled = "DIBATALKAN"
+	LoanFailed    = "BERMASALAH"
+
+	maxJerseyNumber = 99
+)
+
+type PlayerLoanService interface {
+	Create(l *models.PlayerLoan, override bool) error
+	GetByPlayer(playerID uint) ([]models.PlayerLoan, error)
+	ExerciseOption(playerID, loanID uint, contract *models.PlayerContract) (*models.PlayerLoan, error)
+	ProcessDue(now time.Time) error
+}
+
+type playerLoanService struct {
+	repo       repository.PlayerLoanRepository
+	playerRepo repository.PlayerRepository
+	teamRepo   repository.TeamRepository
+	windowRepo repository.TransferWindowRepository
+	uow        repository.UnitOfWork
+}
+
+func NewPlayerLoanService(
+	r repository.PlayerLoanRepository,
+	pr repository.PlayerRepository,
+	tr repository.TeamRepository,
+	twr repository.TransferWindowRepository,
+	uow repository.UnitOfWork,
+) PlayerLoanService {
+	return &playerLoanService{
+		repo:       r,
+		playerRepo: pr,
+		teamRepo:   tr,
+		windowRepo: twr,
+		uow:        uow,
+	}
+}
+
+func (s *playerLoanService) Create(l *models.PlayerLoan, override bool) error {
+	player, err := s.playerRepo.GetByID(l.PlayerID)
+	if err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	if player.TeamID == l.LoanTeamID {
+		return apperror.NewValidationError("pemain sudah berada di team peminjam")
+	}
+	if _, err := s.teamRepo.GetByID(l.LoanTeamID); err != nil {
+		return apperror.NewNotFoundError("team peminjam tidak ditemukan")
+	}
+
+	now := time.Now()
+	if l.StartDate.IsZero() {
+		l.StartDate = now
+	}
+	if !l.EndDate.After(l.StartDate) {
+		return apperror.NewValidationError("end_date harus setelah start_date")
+	}
+	if !l.EndDate.After(now) {
+		return apperror.NewValidationError("end_date harus di masa depan")
+	}
+
+	windowID, overridden, err := checkTransferWindow(s.windowRepo, l.LoanTeamID, l.StartDate, override)
+	if err != nil {
+		return err
+	}
+
+	l.ParentTeamID = player.TeamID
+	l.ParentJerseyNumber = player.JerseyNumber
+	l.TransferWindowID = windowID
+	l.WindowOverride = overridden
+	l.Status = LoanScheduled
+
+	return s.uow.Do(func(r repository.Repositories) error {
+		if _, err := r.PlayerLoans.FindOpenByPlayer(l.PlayerID); err == nil {
+			return apperror.NewConflictError("pemain sudah memiliki peminjaman yang berjalan atau terjadwal")
+		}
+		if _, err := r.Players.FindJerseyNumber(l.LoanTeamID, l.LoanJerseyNumber); err == nil {
+			return apperror.NewConflictError("nomor punggung sudah dipakai di team peminjam")
+		}
+		if contract, err := r.PlayerContracts.FindCurrent(l.PlayerID); err == nil && l.EndDate.After(contract.EndDate) {
+			return apperror.NewValidationError("akhir peminjaman tidak boleh melewati akhir kontrak pemain di team asal")
+		}
+
+		if err := r.PlayerLoans.Create(l); err != nil {
+			return apperror.NewInternalError("gagal menyimpan peminjaman")
+		}
+
+		if l.StartDate.After(now) {
+			return nil
+		}
+		return startLoan(r, l)
+	})
+}
+
+func (s *playerLoanService) GetByPlayer(playerID uint) ([]models.PlayerLoan, error) {
+	if _, err := s.playerRepo.GetByID(playerID); err != nil {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+
+	list, err := s.repo.GetByPlayer(playerID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data peminjaman")
+	}
+	return list, nil
+}
+
+func (s *playerLoanService) ExerciseOption(playerID, loanID uint, contract *models.PlayerContract) (*models.PlayerLoan, error) {
+	now := time.Now()
+	contract.StartDate = now
+	if err := validateContract(contract, now); err != nil {
+		return nil, err
+	}
+
+	err := s.uow.Do(func(r repository.Repositories) error {
+		loan, err := r.PlayerLoans.GetForUpdate(loanID)
+		if err != nil || loan.PlayerID != playerID {
+			return apperror.NewNotFoundError("peminjaman tidak ditemukan")
+		}
+		if loan.Status != LoanActive {
+			return apperror.NewValidationError("opsi pembelian hanya untuk peminjaman yang sedang berjalan")
+		}
+		if !loan.OptionToBuy {
+			return apperror.NewValidationError("peminjaman ini tidak memiliki opsi pembelian")
+		}
+
+		transfer := &models.PlayerTransfer{
+			PlayerID:     loan.PlayerID,
+			OldTeamID:    loan.ParentTeamID,
+			NewTeamID:    loan.LoanTeamID,
+			JerseyNumber: loan.LoanJerseyNumber,
+			Type:         TransferPermanent,
+			LoanID:       &loan.ID,
+		}
+		if err := r.PlayerTransfers.Create(transfer); err != nil {
+			return apperror.NewInternalError("gagal menyimpan riwayat transfer")
+		}
+
+		loan.Status = LoanPermanent
+		if err := r.PlayerLoans.Update(loan); err != nil {
+			return apperror.NewInternalError("gagal memperbarui peminjaman")
+		}
+
+		player, err := r.Players.GetByID(loan.PlayerID)
+		if err != nil {
+			return apperror.NewNotFoundError("pemain tidak ditemukan")
+		}
+		return signContract(r, player, contract, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	loan, err := s.repo.GetByID(loanID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data peminjaman")
+	}
+	return loan, nil
+}
+
+func (s *playerLoanService) ProcessDue(now time.Time) error {
+	starting, err := s.repo.GetDueToStart(now)
+	if err != nil {
+		return err
+	}
+	for _, due := range starting {
+		loanID := due.ID
+		err := s.uow.Do(func(r repository.Repositories) error {
+			loan, err := r.PlayerLoans.GetForUpdate(loanID)
+			if err != nil {
+				return err
+			}
+			if loan.Status != LoanScheduled {
+				return nil
+			}
+			return startLoan(r, loan)
+		})
+		if err != nil {
+			log.Printf("loan %d could not start, cancelling: %v", loanID, err)
+			if err := s.cancelScheduled(loanID); err != nil {
+				log.Printf("loan %d cancel failed: %v", loanID, err)
+			}
+		}
+	}
+
+	ending, err := s.repo.GetDueToEnd(now)
+	if err != nil {
+		return err
+	}
+	for _, due := range ending {
+		loanID := due.ID
+		ended := false
+		err := s.uow.Do(func(r repository.Repositories) error {
+			loan, err := r.PlayerLoans.GetForUpdate(loanID)
+			if err != nil {
+				return err
+			}
+			if loan.Status != LoanActive {
+				return nil
+			}
+			ended = true
+			return endLoan(r, loan)
+		})
+		if err != nil {
+			if appErr, ok := err.(*apperror.AppError); ok && appErr.Code < 500 {
+				log.Printf("loan %d cannot be returned, flagging for manual handling: %v", loanID, err)
+				if err := s.flagActive(loanID, appErr.Message); err != nil {
+					log.Printf("loan %d flag failed: %v", loanID, err)
+				}
+				continue
+			}
+			log.Printf("loan %d return failed: %v", loanID, err)
+			continue
+		}
+		if ended {
+			log.Printf("loan %d ended, player %d returned to team %d", loanID, due.PlayerID, due.ParentTeamID)
+		}
+	}
+
+	return nil
+}
+
+func (s *playerLoanService) cancelScheduled(loanID uint) error {
+	return s.uow.Do(func(r repository.Repositories) error {
+		loan, err := r.PlayerLoans.GetForUpdate(loanID)
+		if err != nil {
+			return err
+		}
+		if loan.Status != LoanScheduled {
+			return nil
+		}
+		loan.Status = LoanCancelled
+		return r.PlayerLoans.Update(loan)
+	})
+}
+
+func (s *playerLoanService) flagActive(loanID uint, reason string) error {
+	return s.uow.Do(func(r repository.Repositories) error {
+		loan, err := r.PlayerLoans.GetForUpdate(loanID)
+		if err != nil {
+			return err
+		}
+		if loan.Status != LoanActive {
+			return nil
+		}
+		loan.Status = LoanFailed
+		loan.FailureReason = reason
+		return r.PlayerLoans.Update(loan)
+	})
+}
+
+func startLoan(r repository.Repositories, loan *models.PlayerLoan) error {
+	player, err := r.Players.GetByID(loan.PlayerID)
+	if err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	if player.TeamID != loan.ParentTeamID {
+		return apperror.NewValidationError("pemain sudah tidak berada di team asal")
+	}
+	if _, err := r.Players.FindJerseyNumber(loan.LoanTeamID, loan.LoanJerseyNumber); err == nil {
+		return apperror.NewConflictError("nomor punggung sudah dipakai di team peminjam")
+	}
+	if err := checkSquadLimits(r, loan.LoanTeamID, player.ID, player.Nationality); err != nil {
+		return err
+	}
+
+	transfer := &models.PlayerTransfer{
+		PlayerID:     player.ID,
+		OldTeamID:    loan.ParentTeamID,
+		NewTeamID:    loan.LoanTeamID,
+		JerseyNumber: loan.LoanJerseyNumber,
+		Type:         TransferLoan,
+		LoanID:       &loan.ID,
+
+		TransferWindowID: loan.TransferWindowID,
+		WindowOverride:   loan.WindowOverride,
+	}
+	if err := r.PlayerTransfers.Create(transfer); err != nil {
+		return apperror.NewInternalError("gagal menyimpan riwayat transfer")
+	}
+
+	player.TeamID = loan.LoanTeamID
+	player.JerseyNumber = loan.LoanJerseyNumber
+	if err := r.Players.Update(player); err != nil {
+		return apperror.NewInternalError("gagal memperbarui data pemain")
+	}
+
+	loan.Status = LoanActive
+	if err := r.PlayerLoans.Update(loan); err != nil {
+		return apperror.NewInternalError("gagal memperbarui peminjaman")
+	}
+	return nil
+}
+
+func endLoan(r repository.Repositories, loan *models.PlayerLoan) error {
+	player, err := r.Players.GetByID(loan.PlayerID)
+	if err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	if player.TeamID != loan.LoanTeamID {
+		return apperror.NewValidationError("pemain sudah tidak berada di team peminjam")
+	}
+
+	jersey, err := freeJerseyNumber(r, loan.ParentTeamID, loan.ParentJerseyNumber)
+	if err != nil {
+		return err
+	}
+
+	transfer := &models.PlayerTransfer{
+		PlayerID:     player.ID,
+		OldTeamID:    player.TeamID,
+		NewTeamID:    loan.ParentTeamID,
+		JerseyNumber: jersey,
+		Type:         TransferLoanEnd,
+		LoanID:       &loan.ID,
+	}
+	if err := r.PlayerTransfers.Create(transfer); err != nil {
+		return apperror.NewInternalError("gagal menyimpan riwayat transfer")
+	}
+
+	player.TeamID = loan.ParentTeamID
+	player.JerseyNumber = jersey
+	if err := r.Players.Update(player); err != nil {
+		return apperror.NewInternalError("gagal memperbarui data pemain")
+	}
+	if err := syncContractJersey(r, player); err != nil {
+		return err
+	}
+
+	loan.Status = LoanEnded
+	if err := r.PlayerLoans.Update(loan); err != nil {
+		return apperror.NewInternalError("gagal memperbarui peminjaman")
+	}
+	return nil
+}
+
+func freeJerseyNumber(r repository.Repositories, teamID uint, preferred int) (int, error) {
+	if _, err := r.Players.FindJerseyNumber(teamID, preferred); err != nil {
+		return preferred, nil
+	}
+
+	for n := 1; n <= maxJerseyNumber; n++ {
+		if _, err := r.Players.FindJerseyNumber(teamID, n); err != nil {
+			return n, nil
+		}
+	}
+	return 0, apperror.NewConflictError("tidak ada nomor punggung tersisa di team " + strconv.Itoa(int(teamID)))
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"strings"
+	"time"
 
+	"football-backend/internal/dto"
 	apperror "football-backend/internal/errors"
 	"football-backend/internal/models"
 	"football-backend/internal/repository"
@@ -10,23 +12,50 @@ import (
 )
 
 type PlayerService interface {
-	Create(p *models.Player) error
+	Create(p *models.Player, contract *models.PlayerContract) error
 	Update(p *models.Player) error
 	Delete(id uint) error
 	GetList(q utils.QueryParams) (map[string]interface{}, error)
 	GetByID(id uint) (*models.Player, error)
 	GetByTeam(teamID uint) ([]models.Player, error)
-	TransferPlayer(playerID, newTeamID uint, newJersey int) error
+	TransferPlayer(playerID uint, contract *models.PlayerContract, override bool) error
+	Transfers(playerID uint) ([]models.PlayerTransfer, error)
+	Contracts(playerID uint) ([]models.PlayerContract, error)
+	UpdateContract(playerID uint, changes *models.PlayerContract) (*models.PlayerContract, error)
+	ExpiringContracts(days int, teamID uint) ([]models.PlayerContract, error)
+	Stats(playerID uint, f repository.PlayerStatFilter) (*dto.PlayerStatDTO, error)
+	Leaderboard(metric string, f repository.PlayerStatFilter, limit int) ([]dto.PlayerStatDTO, error)
+}
+
+const (
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+)
+
+var playerStatMetrics = map[string]bool{
+	"appearances":      true,
+	"minutes":          true,
+	"goals":            true,
+	"assists":          true,
+	"penalty_goals":    true,
+	"penalties_missed": true,
+	"yellow_cards":     true,
+	"red_cards":        true,
+	"goals_per_90":     true,
 }
 
 type playerService struct {
 	repo         repository.PlayerRepository
 	transferRepo repository.PlayerTransferRepository
 	teamRepo     repository.TeamRepository
+	statRepo     repository.PlayerStatRepository
+	windowRepo   repository.TransferWindowRepository
+	contractRepo repository.PlayerContractRepository
+	uow          repository.UnitOfWork
 }
 
-func NewPlayerService(repo repository.PlayerRepository, tRepo repository.PlayerTransferRepository, teamRepo repository.TeamRepository) PlayerService {
-	return &playerService{repo: repo, transferRepo: tRepo, teamRepo: teamRepo}
+func NewPlayerService(repo repository.PlayerRepository, tRepo repository.PlayerTransferRepository, teamRepo repository.TeamRepository, statRepo repository.PlayerStatRepository, windowRepo repository.TransferWindowRepository, contractRepo repository.PlayerContractRepository, uow repository.UnitOfWork) PlayerService {
+	return &playerService{repo: repo, transferRepo: tRepo, teamRepo: teamRepo, statRepo: statRepo, windowRepo: windowRepo, contractRepo: contractRepo, uow: uow}
 }
 
 func validatePosition(pos string) bool {
@@ -59,7 +88,7 @@ func (s *playerService) GetList(q utils.QueryParams) (map[string]interface{}, er
 	}, nil
 }
 
-func (s *playerService) Create(p *models.Player) error {
+func (s *playerService) Create(p *models.Player, contract *models.PlayerContract) error {
 	if p.Name == "" {
 		return apperror.NewValidationError("nama pemain wajib diisi")
 	}
@@ -76,15 +105,35 @@ func (s *playerService) Create(p *models.Player) error {
 		return apperror.NewValidationError("tidak bisa menambahkan pemain ke team yang sudah dihapus")
 	}
 
-	if _, err := s.repo.FindJerseyNumber(p.TeamID, p.JerseyNumber); err == nil {
-		return apperror.NewConflictError("nomor punggung sudah digunakan dalam tim ini")
+	now := time.Now()
+	if contract != nil {
+		if err := validateContract(contract, now); err != nil {
+			return err
+		}
 	}
 
-	if err := s.repo.Create(p); err != nil {
-		if strings.Contains(err.Error(), "Duplicate entry") {
-			return apperror.NewConflictError("nomor punggung sudah digunakan")
+	err = s.uow.Do(func(r repository.Repositories) error {
+		if _, err := r.Players.FindJerseyNumber(p.TeamID, p.JerseyNumber); err == nil {
+			return apperror.NewConflictError("nomor punggung sudah digunakan dalam tim ini")
+		}
+		if err := checkSquadLimits(r, p.TeamID, 0, p.Nationality); err != nil {
+			return err
+		}
+
+		if err := r.Players.Create(p); err != nil {
+			if strings.Contains(err.Error(), "Duplicate entry") {
+				return apperror.NewConflictError("nomor punggung sudah digunakan")
+			}
+			return apperror.NewInternalError("gagal membuat pemain")
+		}
+
+		if contract == nil {
+			return nil
 		}
-		return apperror.NewInternalError("gagal membuat pemain")
+		return signContract(r, p, contract, now)
+	})
+	if err != nil {
+		return err
 	}
 
 	saved, err := s.repo.GetByID(p.ID)
@@ -101,15 +150,31 @@ func (s *playerService) Update(p *models.Player) error {
 		return apperror.NewValidationError("posisi pemain tidak valid")
 	}
 
+	current, err := s.repo.GetByID(p.ID)
+	if err != nil {
+		return apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	if current.TeamID != p.TeamID {
+		return apperror.NewValidationError("team pemain hanya dapat diubah melalui transfer")
+	}
+
 	exist, err := s.repo.FindJerseyNumber(p.TeamID, p.JerseyNumber)
 	if err == nil && exist != nil && exist.ID != p.ID {
 		return apperror.NewConflictError("nomor punggung sudah digunakan pemain lain")
 	}
 
-	if err := s.repo.Update(p); err != nil {
-		return apperror.NewInternalError("gagal memperbarui pemain")
-	}
-	return nil
+	return s.uow.Do(func(r repository.Repositories) error {
+		if !strings.EqualFold(current.Nationality, p.Nationality) {
+			if err := checkSquadLimits(r, p.TeamID, p.ID, p.Nationality); err != nil {
+				return err
+			}
+		}
+
+		if err := r.Players.Update(p); err != nil {
+			return apperror.NewInternalError("gagal memperbarui pemain")
+		}
+		return syncContractJersey(r, p)
+	})
 }
 
 func (s *playerService) Delete(id uint) error {
@@ -139,11 +204,42 @@ func (s *playerService) GetByTeam(teamID uint) ([]models.Player, error) {
 	return list, nil
 }
 
-func (s *playerService) TransferPlayer(playerID, newTeamID uint, newJersey int) error {
+const (
+	TransferPermanent = "PERMANEN"
+	TransferLoan      = "PINJAMAN"
+	TransferLoanEnd   = "AKHIR_PINJAMAN"
+)
+
+func checkTransferWindow(windowRepo repository.TransferWindowRepository, teamID uint, at time.Time, override bool) (*uint, bool, error) {
+	total, err := windowRepo.CountForTeam(teamID)
+	if err != nil {
+		return nil, false, apperror.NewInternalError("gagal memeriksa jendela transfer")
+	}
+	if total == 0 {
+		return nil, false, nil
+	}
+
+	window, err := windowRepo.FindOpenForTeam(teamID, at)
+	if err != nil {
+		if override {
+			return nil, true, nil
+		}
+		return nil, false, apperror.NewValidationError("jendela transfer sedang ditutup")
+	}
+	return &window.ID, false, nil
+}
+
+func (s *playerService) TransferPlayer(playerID uint, contract *models.PlayerContract, override bool) error {
+	newTeamID := contract.TeamID
+	newJersey := contract.JerseyNumber
+
 	player, err := s.repo.GetByID(playerID)
 	if err != nil {
 		return apperror.NewNotFoundError("pemain tidak ditemukan")
 	}
+	if player.TeamID == newTeamID {
+		return apperror.NewValidationError("pemain sudah berada di team tujuan")
+	}
 
 	newTeam, err := s.teamRepo.GetByID(newTeamID)
 	if err != nil {
@@ -153,26 +249,105 @@ func (s *playerService) TransferPlayer(playerID, newTeamID uint, newJersey int)
 		return apperror.NewValidationError("tidak bisa mentransfer pemain ke team yang sudah dihapus")
 	}
 
-	exist, _ := s.repo.FindJerseyNumber(newTeamID, newJersey)
-	if exist != nil {
-		return apperror.NewConflictError("nomor punggung sudah dipakai di tim baru")
+	now := time.Now()
+	contract.StartDate = now
+	if err := validateContract(contract, now); err != nil {
+		return err
+	}
+
+	windowID, overridden, err := checkTransferWindow(s.windowRepo, newTeamID, now, override)
+	if err != nil {
+		return err
+	}
+
+	return s.uow.Do(func(r repository.Repositories) error {
+		if _, err := r.PlayerLoans.FindOpenByPlayer(player.ID); err == nil {
+			return apperror.NewValidationError("pemain sedang atau akan dipinjamkan; selesaikan peminjaman terlebih dahulu")
+		}
+
+		if _, err := r.Players.FindJerseyNumber(newTeamID, newJersey); err == nil {
+			return apperror.NewConflictError("nomor punggung sudah dipakai di tim baru")
+		}
+		if err := checkSquadLimits(r, newTeamID, player.ID, player.Nationality); err != nil {
+			return err
+		}
+
+		transfer := &models.PlayerTransfer{
+			PlayerID:     player.ID,
+			OldTeamID:    player.TeamID,
+			NewTeamID:    newTeamID,
+			JerseyNumber: newJersey,
+			Type:         TransferPermanent,
+
+			TransferWindowID: windowID,
+			WindowOverride:   overridden,
+		}
+		if err := r.PlayerTransfers.Create(transfer); err != nil {
+			return apperror.NewInternalError("gagal menyimpan riwayat transfer")
+		}
+
+		player.TeamID = newTeamID
+		player.JerseyNumber = newJersey
+
+		if err := r.Players.Update(player); err != nil {
+			return apperror.NewInternalError("gagal memperbarui data pemain")
+		}
+		return signContract(r, player, contract, now)
+	})
+}
+
+func (s *playerService) Transfers(playerID uint) ([]models.PlayerTransfer, error) {
+	if _, err := s.repo.GetByID(playerID); err != nil {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
 	}
 
-	transfer := &models.PlayerTransfer{
-		PlayerID:     player.ID,
-		OldTeamID:    player.TeamID,
-		NewTeamID:    newTeamID,
-		JerseyNumber: newJersey,
+	list, err := s.transferRepo.GetByPlayer(playerID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil riwayat transfer")
 	}
-	if err := s.transferRepo.Create(transfer); err != nil {
-		return apperror.NewInternalError("gagal menyimpan riwayat transfer")
+	return list, nil
+}
+
+func (s *playerService) Stats(playerID uint, f repository.PlayerStatFilter) (*dto.PlayerStatDTO, error) {
+	if _, err := s.repo.GetByID(playerID); err != nil {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
 	}
 
-	player.TeamID = newTeamID
-	player.JerseyNumber = newJersey
+	f.PlayerID = playerID
+	f.MinMinutes = 0
 
-	if err := s.repo.Update(player); err != nil {
-		return apperror.NewInternalError("gagal memperbarui data pemain")
+	list, err := s.statRepo.GetStats(f)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil statistik pemain")
 	}
-	return nil
+	if len(list) == 0 {
+		return nil, apperror.NewNotFoundError("pemain tidak ditemukan")
+	}
+	return &list[0], nil
+}
+
+func (s *playerService) Leaderboard(metric string, f repository.PlayerStatFilter, limit int) ([]dto.PlayerStatDTO, error) {
+	if metric == "" {
+		metric = "goals"
+	}
+	if !playerStatMetrics[metric] {
+		return nil, apperror.NewValidationError("metric harus salah satu dari appearances, minutes, goals, assists, penalty_goals, penalties_missed, yellow_cards, red_cards, goals_per_90")
+	}
+	if f.MinMinutes < 0 {
+		return nil, apperror.NewValidationError("min_minutes tidak boleh negatif")
+	}
+
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	if limit > maxLeaderboardLimit {
+		limit = maxLeaderboardLimit
+	}
+
+	f.PlayerID = 0
+	list, err := s.statRepo.Leaderboard(metric, f, limit)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil leaderboard pemain")
+	}
+	return list, nil
 }
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"football-backend/internal/dto"
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TiebreakGoalDifference   = "SELISIH_GOL"
+	TiebreakGoalsFor         = "GOL_MEMASUKKAN"
+	TiebreakWins             = "JUMLAH_MENANG"
+	TiebreakAwayGoals        = "GOL_TANDANG"
+	TiebreakHeadToHeadPoints = "HEAD_TO_HEAD_POIN"
+	TiebreakHeadToHeadGD     = "HEAD_TO_HEAD_SELISIH_GOL"
+	TiebreakHeadToHeadGoals  = "HEAD_TO_HEAD_GOL"
+	TiebreakFairPlay         = "FAIR_PLAY"
+)
+
+const (
+	VenueHome = "HOME"
+	VenueAway = "AWAY"
+)
+
+const formLength = 5
+
+var validTiebreakers = map[string]bool{
+	TiebreakGoalDifference:   true,
+	TiebreakGoalsFor:         true,
+	TiebreakWins:             true,
+	TiebreakAwayGoals:        true,
+	TiebreakHeadToHeadPoints: true,
+	TiebreakHeadToHeadGD:     true,
+	TiebreakHeadToHeadGoals:  true,
+	TiebreakFairPlay:         true,
+}
+
+type standingConfig struct {
+	rule       models.StandingRule
+	deductions map[uint]int
+	fairPlay   map[uint]int
+	venue      string
+}
+
+func defaultStandingRule() models.StandingRule {
+	return models.StandingRule{
+		PointsWin:   3,
+		PointsDraw:  1,
+		PointsLoss:  0,
+		Tiebreakers: TiebreakGoalDifference + "," + TiebreakGoalsFor,
+	}
+}
+
+func parseTiebreakers(raw string) []string {
+	list := []string{}
+	for _, t := range strings.Split(raw, ",") {
+		t = strings.TrimSpace(t)
+		if t != "" {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+func loadStandingConfig(
+	seasonID uint,
+	seasonRepo repository.SeasonRepository,
+	ruleRepo repository.StandingRuleRepository,
+	sanctionRepo repository.TeamSanctionRepository,
+	eventRepo repository.MatchEventRepository,
+) (standingConfig, error) {
+	cfg := standingConfig{
+		rule:       defaultStandingRule(),
+		deductions: map[uint]int{},
+		fairPlay:   map[uint]int{},
+	}
+
+	if seasonID == 0 {
+		return cfg, nil
+	}
+
+	season, err := seasonRepo.GetByID(seasonID)
+	if err != nil {
+		return cfg, apperror.NewNotFoundError("musim tidak ditemukan")
+	}
+
+	rule, err := ruleRepo.GetByCompetition(season.CompetitionID)
+	if err == nil {
+		cfg.rule = *rule
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return cfg, apperror.NewInternalError("gagal mengambil aturan klasemen")
+	}
+
+	sanctions, err := sanctionRepo.GetBySeason(seasonID)
+	if err != nil {
+		return cfg, apperror.NewInternalError("gagal mengambil sanksi tim")
+	}
+	for _, s := range sanctions {
+		cfg.deductions[s.TeamID] += s.PointsDeducted
+		cfg.fairPlay[s.TeamID] += s.FairPlayPoints
+	}
+
+	cards, err := eventRepo.GetCardsBySeason(seasonID)
+	if err != nil {
+		return cfg, apperror.NewInternalError("gagal mengambil data kartu")
+	}
+	for _, e := range cards {
+		cfg.fairPlay[e.TeamID] += fairPlayCardPoints[e.Type]
+	}
+
+	return cfg, nil
+}
+
+type standingRow struct {
+	dto.StandingDTO
+	awayGoals int
+	results   []string
+}
+
+func buildStanding(teams []models.Team, matches []models.Match, cfg standingConfig) []dto.StandingDTO {
+	rows := map[uint]*standingRow{}
+
+	for _, t := range teams {
+		rows[t.ID] = &standingRow{StandingDTO: dto.StandingDTO{
+			TeamID:   t.ID,
+			TeamName: t.Name,
+		}}
+	}
+
+	for _, m := range matches {
+		home := rows[m.HomeTeamID]
+		away := rows[m.AwayTeamID]
+		if home == nil || away == nil {
+			continue
+		}
+
+		homeGoals, awayGoals := matchScore(&m)
+
+		if cfg.venue != VenueAway {
+			home.record(homeGoals, awayGoals)
+		}
+		if cfg.venue != VenueHome {
+			away.record(awayGoals, homeGoals)
+			away.awayGoals += awayGoals
+		}
+	}
+
+	list := []*standingRow{}
+	for _, t := range teams {
+		r := rows[t.ID]
+		r.GoalDifference = r.GoalsFor - r.GoalsAgainst
+		r.Form = strings.Join(r.results, "")
+		if cfg.venue == "" {
+			r.PointsDeducted = cfg.deductions[t.ID]
+		}
+		r.FairPlayPoints = cfg.fairPlay[t.ID]
+		r.Points = r.Wins*cfg.rule.PointsWin + r.Draws*cfg.rule.PointsDraw + r.Losses*cfg.rule.PointsLoss - r.PointsDeducted
+		list = append(list, r)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Points > list[j].Points })
+
+	ordered := []*standingRow{}
+	criteria := parseTiebreakers(cfg.rule.Tiebreakers)
+	for start := 0; start < len(list); {
+		end := start + 1
+		for end < len(list) && list[end].Points == list[start].Points {
+			end++
+		}
+		ordered = append(ordered, rankTied(list[start:end], criteria, matches, cfg)...)
+		start = end
+	}
+
+	result := make([]dto.StandingDTO, 0, len(ordered))
+	for i, r := range ordered {
+		r.Position = i + 1
+		result = append(result, r.StandingDTO)
+	}
+	return result
+}
+
+func (r *standingRow) record(scored, conceded int) {
+	r.Played++
+	r.GoalsFor += scored
+	r.GoalsAgainst += conceded
+
+	result := "D"
+	switch {
+	case scored > conceded:
+		r.Wins++
+		result = "W"
+	case scored < conceded:
+		r.Losses++
+		result = "L"
+	default:
+		r.Draws++
+	}
+
+	r.results = append(r.results, result)
+	if len(r.results) > formLength {
+		r.results = r.results[1:]
+	}
+}
+
+func buildPositionHistory(teams []models.Team, matches []models.Match, cfg standingConfig) []dto.PositionHistoryDTO {
+	type matchday struct {
+		date    time.Time
+		matches []models.Match
+	}
+
+	days := []*matchday{}
+	byKey := map[string]*matchday{}
+
+	for _, m := range matches {
+		key := m.MatchDateTime.Format(time.DateOnly)
+		if m.Round > 0 {
+			key = fmt.Sprintf("R%d", m.Round)
+		}
+
+		d := byKey[key]
+		if d == nil {
+			d = &matchday{date: m.MatchDateTime}
+			byKey[key] = d
+			days = append(days, d)
+		}
+		if m.MatchDateTime.After(d.date) {
+			d.date = m.MatchDateTime
+		}
+		d.matches = append(d.matches, m)
+	}
+
+	sort.SliceStable(days, func(i, j int) bool { return days[i].date.Before(days[j].date) })
+
+	history := map[uint]*dto.PositionHistoryDTO{}
+	for _, t := range teams {
+		history[t.ID] = &dto.PositionHistoryDTO{
+			TeamID:    t.ID,
+			TeamName:  t.Name,
+			Positions: []dto.MatchdayPositionDTO{},
+		}
+	}
+
+	played := []models.Match{}
+	for i, d := range days {
+		played = append(played, d.matches...)
+
+		for _, row := range buildStanding(teams, played, cfg) {
+			history[row.TeamID].Positions = append(history[row.TeamID].Positions, dto.MatchdayPositionDTO{
+				Matchday: i + 1,
+				Date:     d.date.Format(time.DateOnly),
+				Position: row.Position,
+				Points:   row.Points,
+			})
+		}
+	}
+
+	result := make([]dto.PositionHistoryDTO, 0, len(teams))
+	for _, t := range teams {
+		result = append(result, *history[t.ID])
+	}
+	return result
+}
+
+func rankTied(cluster []*standingRow, criteria []string, matches []models.Match, cfg standingConfig) []*standingRow {
+	if len(cluster) <= 1 || len(criteria) == 0 {
+		return cluster
+	}
+
+	criterion := criteria[0]
+	values := tiebreakValues(criterion, cluster, matches, cfg)
+
+	sorted := append([]*standingRow{}, cluster...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return values[sorted[i].TeamID] > values[sorted[j].TeamID]
+	})
+
+	result := []*standingRow{}
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && values[sorted[end].TeamID] == values[sorted[start].TeamID] {
+			end++
+		}
+
+		sub := sorted[start:end]
+		if end-start < len(sorted) {
+			for _, r := range sub {
+				r.Tiebreaker = criterion
+			}
+		}
+		result = append(result, rankTied(sub, criteria[1:], matches, cfg)...)
+		start = end
+	}
+
+	return result
+}
+
+func tiebreakValues(criterion string, cluster []*standingRow, matches []models.Match, cfg standingConfig) map[uint]int {
+	values := map[uint]int{}
+
+	switch criterion {
+	case TiebreakGoalDifference:
+		for _, r := range cluster {
+			values[r.TeamID] = r.GoalDifference
+		}
+	case TiebreakGoalsFor:
+		for _, r := range cluster {
+			values[r.TeamID] = r.GoalsFor
+		}
+	case TiebreakWins:
+		for _, r := range cluster {
+			values[r.TeamID] = r.Wins
+		}
+	case TiebreakAwayGoals:
+		for _, r := range cluster {
+			values[r.TeamID] = r.awayGoals
+		}
+	case TiebreakFairPlay:
+		for _, r := range cluster {
+			values[r.TeamID] = -r.FairPlayPoints
+		}
+	case TiebreakHeadToHeadPoints, TiebreakHeadToHeadGD, TiebreakHeadToHeadGoals:
+		inCluster := map[uint]bool{}
+		for _, r := range cluster {
+			inCluster[r.TeamID] = true
+		}
+
+		points := map[uint]int{}
+		diff := map[uint]int{}
+		goals := map[uint]int{}
+
+		for _, m := range matches {
+			if !inCluster[m.HomeTeamID] || !inCluster[m.AwayTeamID] {
+				continue
+			}
+			h, a := matchScore(&m)
+			goals[m.HomeTeamID] += h
+			goals[m.AwayTeamID] += a
+			diff[m.HomeTeamID] += h - a
+			diff[m.AwayTeamID] += a - h
+
+			switch {
+			case h > a:
+				points[m.HomeTeamID] += cfg.rule.PointsWin
+				points[m.AwayTeamID] += cfg.rule.PointsLoss
+			case a > h:
+				points[m.AwayTeamID] += cfg.rule.PointsWin
+				points[m.HomeTeamID] += cfg.rule.PointsLoss
+			default:
+				points[m.HomeTeamID] += cfg.rule.PointsDraw
+				points[m.AwayTeamID] += cfg.rule.PointsDraw
+			}
+		}
+
+		source := points
+		if criterion == TiebreakHeadToHeadGD {
+			source = diff
+		} else if criterion == TiebreakHeadToHeadGoals {
+			source = goals
+		}
+		for _, r := range cluster {
+			values[r.TeamID] = source[r.TeamID]
+		}
+	}
+
+	return values
+}
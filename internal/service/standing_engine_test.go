@@ -0,0 +1,106 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"football-backend/internal/dto"
+	"football-backend/internal/models"
+)
+
+func TestRankTied(t *testing.T) {
+	row := func(teamID uint, gd, gf, wins, fairPlay, awayGoals int) *standingRow {
+		return &standingRow{
+			StandingDTO: dto.StandingDTO{
+				TeamID:         teamID,
+				GoalDifference: gd,
+				GoalsFor:       gf,
+				Wins:           wins,
+				FairPlayPoints: fairPlay,
+			},
+			awayGoals: awayGoals,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		rows           []*standingRow
+		criteria       []string
+		matches        []models.Match
+		wantOrder      []uint
+		wantTiebreaker []string
+	}{
+		{
+			name:           "goal difference",
+			rows:           []*standingRow{row(1, 2, 5, 1, 0, 0), row(2, 5, 6, 1, 0, 0), row(3, 0, 4, 1, 0, 0)},
+			criteria:       []string{TiebreakGoalDifference},
+			wantOrder:      []uint{2, 1, 3},
+			wantTiebreaker: []string{TiebreakGoalDifference, TiebreakGoalDifference, TiebreakGoalDifference},
+		},
+		{
+			name:           "falls through to goals for",
+			rows:           []*standingRow{row(1, 3, 5, 1, 0, 0), row(2, 3, 7, 1, 0, 0), row(3, 1, 9, 1, 0, 0)},
+			criteria:       []string{TiebreakGoalDifference, TiebreakGoalsFor},
+			wantOrder:      []uint{2, 1, 3},
+			wantTiebreaker: []string{TiebreakGoalsFor, TiebreakGoalsFor, TiebreakGoalDifference},
+		},
+		{
+			name:     "head to head points",
+			rows:     []*standingRow{row(1, 4, 6, 2, 0, 0), row(2, 4, 6, 2, 0, 0)},
+			criteria: []string{TiebreakGoalDifference, TiebreakHeadToHeadPoints},
+			matches: []models.Match{
+				testMatch(1, 2, 0, 1),
+				testMatch(1, 3, 4, 0),
+			},
+			wantOrder:      []uint{2, 1},
+			wantTiebreaker: []string{TiebreakHeadToHeadPoints, TiebreakHeadToHeadPoints},
+		},
+		{
+			name:           "fair play prefers fewer points",
+			rows:           []*standingRow{row(1, 0, 3, 1, 7, 0), row(2, 0, 3, 1, 2, 0)},
+			criteria:       []string{TiebreakFairPlay},
+			wantOrder:      []uint{2, 1},
+			wantTiebreaker: []string{TiebreakFairPlay, TiebreakFairPlay},
+		},
+		{
+			name:           "away goals",
+			rows:           []*standingRow{row(1, 1, 4, 1, 0, 1), row(2, 1, 4, 1, 0, 3)},
+			criteria:       []string{TiebreakGoalDifference, TiebreakAwayGoals},
+			wantOrder:      []uint{2, 1},
+			wantTiebreaker: []string{TiebreakAwayGoals, TiebreakAwayGoals},
+		},
+		{
+			name:           "unresolved tie keeps order",
+			rows:           []*standingRow{row(1, 2, 4, 1, 0, 0), row(2, 2, 4, 1, 0, 0)},
+			criteria:       []string{TiebreakGoalDifference, TiebreakGoalsFor},
+			wantOrder:      []uint{1, 2},
+			wantTiebreaker: []string{"", ""},
+		},
+		{
+			name:           "no criteria",
+			rows:           []*standingRow{row(1, 0, 0, 0, 0, 0), row(2, 9, 9, 9, 0, 0)},
+			wantOrder:      []uint{1, 2},
+			wantTiebreaker: []string{"", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := standingConfig{rule: defaultStandingRule()}
+			ranked := rankTied(tt.rows, tt.criteria, tt.matches, cfg)
+
+			order := make([]uint, 0, len(ranked))
+			tiebreakers := make([]string, 0, len(ranked))
+			for _, r := range ranked {
+				order = append(order, r.TeamID)
+				tiebreakers = append(tiebreakers, r.Tiebreaker)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(tiebreakers, tt.wantTiebreaker) {
+				t.Errorf("tiebreakers = %v, want %v", tiebreakers, tt.wantTiebreaker)
+			}
+		})
+	}
+}
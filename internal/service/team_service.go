@@ -2,7 +2,9 @@ package service
 
 import (
 	"strings"
+	"time"
 
+	"football-backend/internal/dto"
 	apperror "football-backend/internal/errors"
 	"football-backend/internal/models"
 	"football-backend/internal/repository"
@@ -15,20 +17,39 @@ type TeamService interface {
 	GetByID(id uint) (*models.Team, error)
 	Update(team *models.Team) error
 	Delete(id uint) error
+	Stats(teamID, seasonID, competitionID uint) (*dto.TeamStatDTO, error)
+	HeadToHead(teamA, teamB uint) (*dto.HeadToHeadDTO, error)
+	Transfers(teamID uint) (*dto.TeamTransfersDTO, error)
 }
 
 type teamService struct {
-	repo repository.TeamRepository
+	repo         repository.TeamRepository
+	matchRepo    repository.MatchRepository
+	transferRepo repository.PlayerTransferRepository
+	venueRepo    repository.VenueRepository
 }
 
-func NewTeamService(r repository.TeamRepository) TeamService {
-	return &teamService{repo: r}
+func NewTeamService(r repository.TeamRepository, mr repository.MatchRepository, tr repository.PlayerTransferRepository, vr repository.VenueRepository) TeamService {
+	return &teamService{repo: r, matchRepo: mr, transferRepo: tr, venueRepo: vr}
+}
+
+func (s *teamService) validateHomeVenue(team *models.Team) error {
+	if team.HomeVenueID == nil {
+		return nil
+	}
+	if _, err := s.venueRepo.GetByID(*team.HomeVenueID); err != nil {
+		return apperror.NewNotFoundError("venue kandang tidak ditemukan")
+	}
+	return nil
 }
 
 func (s *teamService) Create(team *models.Team) error {
 	if team.Name == "" {
 		return apperror.NewValidationError("nama team wajib diisi")
 	}
+	if err := s.validateHomeVenue(team); err != nil {
+		return err
+	}
 
 	if err := s.repo.Create(team); err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") {
@@ -73,6 +94,9 @@ func (s *teamService) Update(team *models.Team) error {
 	if team.Name == "" {
 		return apperror.NewValidationError("nama team wajib diisi")
 	}
+	if err := s.validateHomeVenue(team); err != nil {
+		return err
+	}
 	if err := s.repo.Update(team); err != nil {
 		if strings.Contains(err.Error(), "Duplicate entry") {
 			return apperror.NewConflictError("nama team sudah digunakan")
@@ -92,3 +116,180 @@ func (s *teamService) Delete(id uint) error {
 	}
 	return nil
 }
+
+func teamScore(m *models.Match, teamID uint) (int, int) {
+	home, away := dto.MatchScore(m)
+	if m.HomeTeamID == teamID {
+		return home, away
+	}
+	return away, home
+}
+
+func teamMatchResult(m *models.Match, teamID uint, scored, conceded int) *dto.TeamMatchResultDTO {
+	venue := VenueHome
+	opponent := m.AwayTeam
+	if m.AwayTeamID == teamID {
+		venue = VenueAway
+		opponent = m.HomeTeam
+	}
+
+	return &dto.TeamMatchResultDTO{
+		MatchID:      m.ID,
+		MatchDate:    m.MatchDateTime.Format(time.RFC3339),
+		Venue:        venue,
+		Opponent:     dto.TeamSimpleDTO{ID: opponent.ID, Name: opponent.Name},
+		GoalsFor:     scored,
+		GoalsAgainst: conceded,
+	}
+}
+
+func buildTeamStats(team *models.Team, matches []models.Match) dto.TeamStatDTO {
+	stats := dto.TeamStatDTO{
+		Team: dto.TeamSimpleDTO{ID: team.ID, Name: team.Name},
+	}
+
+	winRun, unbeatenRun, losingRun := 0, 0, 0
+	var current *dto.StreakDTO
+
+	for i := range matches {
+		m := &matches[i]
+		scored, conceded := teamScore(m, team.ID)
+
+		stats.Played++
+		stats.GoalsFor += scored
+		stats.GoalsAgainst += conceded
+		if conceded == 0 {
+			stats.CleanSheets++
+		}
+		if scored == 0 {
+			stats.FailedToScore++
+		}
+
+		result := "D"
+		switch {
+		case scored > conceded:
+			result = "W"
+			stats.Wins++
+			winRun++
+			unbeatenRun++
+			losingRun = 0
+			if stats.BiggestWin == nil || scored-conceded > stats.BiggestWin.GoalsFor-stats.BiggestWin.GoalsAgainst {
+				stats.BiggestWin = teamMatchResult(m, team.ID, scored, conceded)
+			}
+		case scored < conceded:
+			result = "L"
+			stats.Losses++
+			winRun = 0
+			unbeatenRun = 0
+			losingRun++
+			if stats.BiggestLoss == nil || conceded-scored > stats.BiggestLoss.GoalsAgainst-stats.BiggestLoss.GoalsFor {
+				stats.BiggestLoss = teamMatchResult(m, team.ID, scored, conceded)
+			}
+		default:
+			stats.Draws++
+			winRun = 0
+			unbeatenRun++
+			losingRun = 0
+		}
+
+		if current != nil && current.Result == result {
+			current.Length++
+		} else {
+			current = &dto.StreakDTO{Result: result, Length: 1}
+		}
+
+		if winRun > stats.LongestWinStreak {
+			stats.LongestWinStreak = winRun
+		}
+		if unbeatenRun > stats.LongestUnbeatenStreak {
+			stats.LongestUnbeatenStreak = unbeatenRun
+		}
+		if losingRun > stats.LongestLosingStreak {
+			stats.LongestLosingStreak = losingRun
+		}
+	}
+
+	stats.GoalDifference = stats.GoalsFor - stats.GoalsAgainst
+	stats.CurrentStreak = current
+	return stats
+}
+
+func buildHeadToHead(teamA, teamB uint, matches []models.Match) dto.HeadToHeadRecordDTO {
+	record := dto.HeadToHeadRecordDTO{}
+
+	for i := range matches {
+		scoredA, scoredB := teamScore(&matches[i], teamA)
+
+		record.Played++
+		record.TeamAGoals += scoredA
+		record.TeamBGoals += scoredB
+
+		switch {
+		case scoredA > scoredB:
+			record.TeamAWins++
+		case scoredB > scoredA:
+			record.TeamBWins++
+		default:
+			record.Draws++
+		}
+	}
+
+	return record
+}
+
+func (s *teamService) Stats(teamID, seasonID, competitionID uint) (*dto.TeamStatDTO, error) {
+	team, err := s.repo.GetByID(teamID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("team tidak ditemukan")
+	}
+
+	matches, err := s.matchRepo.GetFinishedByTeam(teamID, seasonID, competitionID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil pertandingan team")
+	}
+
+	stats := buildTeamStats(team, matches)
+	return &stats, nil
+}
+
+func (s *teamService) HeadToHead(teamA, teamB uint) (*dto.HeadToHeadDTO, error) {
+	if teamA == teamB {
+		return nil, apperror.NewValidationError("head-to-head membutuhkan dua team yang berbeda")
+	}
+
+	a, err := s.repo.GetByID(teamA)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("team tidak ditemukan")
+	}
+	b, err := s.repo.GetByID(teamB)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("team tidak ditemukan")
+	}
+
+	matches, err := s.matchRepo.GetHeadToHead(teamA, teamB)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil pertemuan kedua team")
+	}
+
+	return &dto.HeadToHeadDTO{
+		TeamA:   dto.TeamSimpleDTO{ID: a.ID, Name: a.Name},
+		TeamB:   dto.TeamSimpleDTO{ID: b.ID, Name: b.Name},
+		Record:  buildHeadToHead(teamA, teamB, matches),
+		Matches: dto.ToMatchDTOList(matches),
+	}, nil
+}
+
+func (s *teamService) Transfers(teamID uint) (*dto.TeamTransfersDTO, error) {
+	team, err := s.repo.GetByID(teamID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("team tidak ditemukan")
+	}
+
+	list, err := s.transferRepo.GetByTeam(teamID)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil riwayat transfer")
+	}
+
+	transfers := dto.ToTeamTransfersDTO(team, list)
+	return &transfers, nil
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+	"football-backend/internal/totp"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	recoveryCodeCount = 10
+	totpSkew          = 1
+)
+
+type TwoFactorService interface {
+	Status(userID uint) (*TwoFactorStatus, error)
+	Enroll(userID uint) (*TwoFactorEnrollment, error)
+	Confirm(userID, sessionID uint, code string) ([]string, error)
+	RegenerateRecoveryCodes(userID uint, code string) ([]string, error)
+	Disable(userID uint, password, code string) error
+}
+
+type TwoFactorStatus struct {
+	Enabled                bool
+	Required               bool
+	RecoveryCodesRemaining int64
+}
+
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+type twoFactorService struct {
+	repo          repository.UserRepository
+	recoveryRepo  repository.RecoveryCodeRepository
+	sessionRepo   repository.UserSessionRepository
+	rtRepo        repository.RefreshTokenRepository
+	uow           repository.UnitOfWork
+	requiredRoles []string
+	issuer        string
+}
+
+func NewTwoFactorService(
+	userRepo repository.UserRepository,
+	recoveryRepo repository.RecoveryCodeRepository,
+	sessionRepo repository.UserSessionRepository,
+	rtRepo repository.RefreshTokenRepository,
+	uow repository.UnitOfWork,
+	requiredRoles []string,
+	issuer string,
+) TwoFactorService {
+	return &twoFactorService{
+		repo:          userRepo,
+		recoveryRepo:  recoveryRepo,
+		sessionRepo:   sessionRepo,
+		rtRepo:        rtRepo,
+		uow:           uow,
+		requiredRoles: requiredRoles,
+		issuer:        issuer,
+	}
+}
+
+func RoleRequiresTwoFactor(requiredRoles []string, role string) bool {
+	for _, r := range requiredRoles {
+		if strings.EqualFold(r, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *twoFactorService) Status(userID uint) (*TwoFactorStatus, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("user tidak ditemukan")
+	}
+
+	status := &TwoFactorStatus{
+		Enabled:  user.TOTPEnabled,
+		Required: RoleRequiresTwoFactor(s.requiredRoles, user.Role),
+	}
+	if user.TOTPEnabled {
+		remaining, err := s.recoveryRepo.CountUnused(userID)
+		if err != nil {
+			return nil, apperror.NewInternalError("gagal mengambil recovery code")
+		}
+		status.RecoveryCodesRemaining = remaining
+	}
+	return status, nil
+}
+
+func (s *twoFactorService) Enroll(userID uint) (*TwoFactorEnrollment, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("user tidak ditemukan")
+	}
+	if user.TOTPEnabled {
+		return nil, apperror.NewConflictError("2FA sudah aktif, nonaktifkan dulu untuk mendaftar ulang")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal membuat secret 2FA")
+	}
+
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	if err := s.repo.Update(user); err != nil {
+		return nil, apperror.NewInternalError("gagal menyimpan secret 2FA")
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.issuer, user.Username, secret),
+	}, nil
+}
+
+func (s *twoFactorService) Confirm(userID, sessionID uint, code string) ([]string, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("user tidak ditemukan")
+	}
+	if user.TOTPEnabled {
+		return nil, apperror.NewConflictError("2FA sudah aktif")
+	}
+	if user.TOTPSecret == "" {
+		return nil, apperror.NewValidationError("belum ada pendaftaran 2FA, panggil enroll terlebih dahulu")
+	}
+
+	step, ok := totp.Validate(user.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, apperror.NewValidationError("kode 2FA salah")
+	}
+
+	codes, hashed, err := newRecoveryCodes()
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal membuat recovery code")
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastStep = step
+	err = s.uow.Do(func(r repository.Repositories) error {
+		advanced, err := r.Users.AdvanceTOTPStep(user.ID, step)
+		if err != nil {
+			return apperror.NewInternalError("gagal mengaktifkan 2FA")
+		}
+		if !advanced {
+			return apperror.NewValidationError("kode 2FA sudah dipakai, tunggu kode berikutnya")
+		}
+		if err := r.Users.Update(user); err != nil {
+			return apperror.NewInternalError("gagal mengaktifkan 2FA")
+		}
+		if err := r.RecoveryCodes.Replace(user.ID, hashed); err != nil {
+			return apperror.NewInternalError("gagal mengaktifkan 2FA")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.sessionRepo.RevokeByUserExcept(user.ID, sessionID, time.Now()); err != nil {
+		return nil, apperror.NewInternalError("gagal mencabut sesi lain")
+	}
+	if err := s.rtRepo.DeleteByUserExceptSession(user.ID, sessionID); err != nil {
+		return nil, apperror.NewInternalError("gagal menghapus refresh token sesi lain")
+	}
+	return codes, nil
+}
+
+func (s *twoFactorService) RegenerateRecoveryCodes(userID uint, code string) ([]string, error) {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("user tidak ditemukan")
+	}
+	if !user.TOTPEnabled {
+		return nil, apperror.NewValidationError("2FA belum aktif")
+	}
+
+	ok, err := verifyTOTP(s.repo, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apperror.NewValidationError("kode 2FA salah")
+	}
+
+	codes, hashed, err := newRecoveryCodes()
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal membuat recovery code")
+	}
+	if err := s.recoveryRepo.Replace(user.ID, hashed); err != nil {
+		return nil, apperror.NewInternalError("gagal menyimpan recovery code")
+	}
+	return codes, nil
+}
+
+func (s *twoFactorService) Disable(userID uint, password, code string) error {
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return apperror.NewNotFoundError("user tidak ditemukan")
+	}
+	if !user.TOTPEnabled {
+		return apperror.NewValidationError("2FA belum aktif")
+	}
+	if RoleRequiresTwoFactor(s.requiredRoles, user.Role) {
+		return apperror.NewValidationError("2FA wajib untuk role " + user.Role + " dan tidak bisa dinonaktifkan")
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return apperror.NewValidationError("password salah")
+	}
+	ok, err := verifySecondFactor(s.repo, s.recoveryRepo, user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperror.NewValidationError("kode 2FA salah")
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	err = s.uow.Do(func(r repository.Repositories) error {
+		if err := r.Users.Update(user); err != nil {
+			return err
+		}
+		return r.RecoveryCodes.DeleteByUser(user.ID)
+	})
+	if err != nil {
+		return apperror.NewInternalError("gagal menonaktifkan 2FA")
+	}
+	return nil
+}
+
+func verifyTOTP(userRepo repository.UserRepository, user *models.User, code string) (bool, error) {
+	step, ok := totp.Validate(user.TOTPSecret, code, time.Now(), totpSkew)
+	if !ok || step <= user.TOTPLastStep {
+		return false, nil
+	}
+
+	advanced, err := userRepo.AdvanceTOTPStep(user.ID, step)
+	if err != nil {
+		return false, apperror.NewInternalError("gagal memperbarui status 2FA")
+	}
+	if advanced {
+		user.TOTPLastStep = step
+	}
+	return advanced, nil
+}
+
+func verifySecondFactor(userRepo repository.UserRepository, recoveryRepo repository.RecoveryCodeRepository, user *models.User, code string) (bool, error) {
+	ok, err := verifyTOTP(userRepo, user, code)
+	if err != nil || ok {
+		return ok, err
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	if len(normalized) != 10 {
+		return false, nil
+	}
+	used, err := recoveryRepo.Use(user.ID, hashToken(normalized), time.Now())
+	if err != nil {
+		return false, apperror.NewInternalError("gagal memeriksa recovery code")
+	}
+	return used, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func newRecoveryCodes() ([]string, []models.RecoveryCode, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashed := make([]models.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 5)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(b)
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashed = append(hashed, models.RecoveryCode{CodeHash: hashToken(raw)})
+	}
+	return codes, hashed, nil
+}
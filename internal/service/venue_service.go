@@ -0,0 +1,137 @@
+package service
+
+import (
+	"strings"
+
+	apperror "football-backend/internal/errors"
+	"football-backend/internal/models"
+	"football-backend/internal/repository"
+	"football-backend/internal/utils"
+)
+
+const (
+	SurfaceNatural   = "RUMPUT_ALAMI"
+	SurfaceSynthetic = "RUMPUT_SINTETIS"
+	SurfaceHybrid    = "HIBRIDA"
+)
+
+type VenueService interface {
+	Create(v *models.Venue) error
+	GetList(q utils.QueryParams) (map[string]interface{}, error)
+	GetByID(id uint) (*models.Venue, error)
+	Update(v *models.Venue) error
+	Delete(id uint) error
+}
+
+type venueService struct {
+	repo repository.VenueRepository
+}
+
+func NewVenueService(r repository.VenueRepository) VenueService {
+	return &venueService{repo: r}
+}
+
+func validateVenue(v *models.Venue) error {
+	if v.Name == "" {
+		return apperror.NewValidationError("nama venue wajib diisi")
+	}
+	if v.Capacity < 0 {
+		return apperror.NewValidationError("kapasitas venue tidak boleh negatif")
+	}
+	if v.Surface == "" {
+		v.Surface = SurfaceNatural
+	}
+	if v.Surface != SurfaceNatural && v.Surface != SurfaceSynthetic && v.Surface != SurfaceHybrid {
+		return apperror.NewValidationError("surface harus RUMPUT_ALAMI, RUMPUT_SINTETIS, atau HIBRIDA")
+	}
+	if (v.Latitude == nil) != (v.Longitude == nil) {
+		return apperror.NewValidationError("latitude dan longitude harus diisi bersamaan")
+	}
+	if v.Latitude != nil && (*v.Latitude < -90 || *v.Latitude > 90 || *v.Longitude < -180 || *v.Longitude > 180) {
+		return apperror.NewValidationError("koordinat venue tidak valid")
+	}
+	return nil
+}
+
+func (s *venueService) Create(v *models.Venue) error {
+	if err := validateVenue(v); err != nil {
+		return err
+	}
+	if err := s.repo.Create(v); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return apperror.NewConflictError("nama venue sudah digunakan")
+		}
+		return apperror.NewInternalError("gagal membuat venue")
+	}
+	return nil
+}
+
+func (s *venueService) GetList(q utils.QueryParams) (map[string]interface{}, error) {
+	items, total, err := s.repo.GetAll(q)
+	if err != nil {
+		return nil, apperror.NewInternalError("gagal mengambil data venue")
+	}
+
+	totalPages := 0
+	if q.Limit > 0 {
+		totalPages = int((total + int64(q.Limit) - 1) / int64(q.Limit))
+	}
+
+	return map[string]interface{}{
+		"items": items,
+		"pagination": map[string]interface{}{
+			"page":        q.Page,
+			"limit":       q.Limit,
+			"total":       total,
+			"total_pages": totalPages,
+		},
+	}, nil
+}
+
+func (s *venueService) GetByID(id uint) (*models.Venue, error) {
+	v, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, apperror.NewNotFoundError("venue tidak ditemukan")
+	}
+	return v, nil
+}
+
+func (s *venueService) Update(v *models.Venue) error {
+	if err := validateVenue(v); err != nil {
+		return err
+	}
+	if err := s.repo.Update(v); err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") {
+			return apperror.NewConflictError("nama venue sudah digunakan")
+		}
+		return apperror.NewInternalError("gagal memperbarui venue")
+	}
+	return nil
+}
+
+func (s *venueService) Delete(id uint) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return apperror.NewNotFoundError("venue tidak ditemukan")
+	}
+
+	teams, err := s.repo.CountHomeTeams(id)
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa penggunaan venue")
+	}
+	if teams > 0 {
+		return apperror.NewConflictError("venue masih menjadi kandang team")
+	}
+
+	upcoming, err := s.repo.CountUpcomingMatches(id)
+	if err != nil {
+		return apperror.NewInternalError("gagal memeriksa penggunaan venue")
+	}
+	if upcoming > 0 {
+		return apperror.NewConflictError("venue masih dipakai pertandingan yang belum selesai")
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return apperror.NewInternalError("gagal menghapus venue")
+	}
+	return nil
+}